@@ -2,6 +2,9 @@ package cmd
 
 import (
 	"github.com/sayan-biswas/kubectl-tekton/internal/cmd/config"
+	"github.com/sayan-biswas/kubectl-tekton/internal/cmd/describe"
+	"github.com/sayan-biswas/kubectl-tekton/internal/cmd/explain"
+	"github.com/sayan-biswas/kubectl-tekton/internal/cmd/find"
 	"github.com/sayan-biswas/kubectl-tekton/internal/cmd/get"
 	"github.com/sayan-biswas/kubectl-tekton/internal/cmd/log"
 	"github.com/spf13/cobra"
@@ -36,6 +39,9 @@ func Command() *cobra.Command {
 
 	c.AddCommand(
 		config.Command(ios),
+		describe.Command(ios, f),
+		explain.Command(ios, f),
+		find.Command(ios, f),
 		get.Command(ios, f),
 		log.Command(ios, f),
 	)
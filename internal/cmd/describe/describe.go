@@ -0,0 +1,173 @@
+package describe
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sayan-biswas/kubectl-tekton/internal/printer"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/config"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/explain"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type describeOptions struct {
+	Namespace string
+	Resource  string
+	Name      string
+	UID       string
+
+	Client     client.Client
+	RESTMapper meta.RESTMapper
+
+	IOStreams *genericiooptions.IOStreams
+	Factory   util.Factory
+}
+
+var (
+	describeLong = templates.LongDesc(i18n.T(`
+		Describe a PipelineRun or TaskRun archived in tekton results.
+
+		Prints the params, workspaces, results and status of the run. PipelineRuns
+		are printed with the status of the steps of all of their TaskRuns.
+
+		You can use --uid to select a specific resource`))
+
+	describeExample = templates.Examples(`
+		# Describe a PipelineRun
+		kubectl tekton describe pr testpr -n default
+
+		# Describe a TaskRun
+		kubectl tekton describe tr testrun -n default
+
+		# Describe a particular run using UID
+		kubectl tekton describe pr testpr --uid f27a6d83-21d3-4256-a8f0-0875b123895f`)
+)
+
+func Command(s *genericiooptions.IOStreams, f util.Factory) *cobra.Command {
+	o := &describeOptions{
+		IOStreams: s,
+		Factory:   f,
+	}
+
+	c := &cobra.Command{
+		Use:     "describe",
+		Aliases: []string{"desc"},
+		Short:   i18n.T("Describe a resource from tekton results"),
+		Long:    describeLong,
+		Example: describeExample,
+		Args:    cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.Complete(args))
+			util.CheckErr(o.Validate())
+			util.CheckErr(o.Run())
+		},
+	}
+
+	c.Flags().StringVarP(&o.UID, "uid", "", "", "UID")
+
+	return c
+}
+
+// Complete completes the required command-line options
+func (o *describeOptions) Complete(args []string) (err error) {
+	o.Namespace, _, err = o.Factory.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	o.RESTMapper, err = o.Factory.ToRESTMapper()
+	if err != nil {
+		return err
+	}
+
+	c, err := config.NewConfig()
+	if err != nil {
+		return err
+	}
+
+	o.Client, err = client.NewClient(c.Get())
+	if err != nil {
+		return err
+	}
+
+	switch len(args) {
+	case 2:
+		o.Resource = args[0]
+		o.Name = args[1]
+	default:
+		return errors.New("invalid arguments, there should be exactly 2 arguments")
+	}
+
+	return nil
+}
+
+// Validate makes sure that provided values for command-line options are valid
+func (o *describeOptions) Validate() error {
+	if o.Namespace == "" {
+		return errors.New("namespace must be specified")
+	}
+	return nil
+}
+
+// Run performs the execution of 'describe' sub command
+func (o *describeOptions) Run() error {
+	gvr, _, err := explain.SplitAndParseResourceRequest(o.Resource, o.RESTMapper)
+	if err != nil {
+		return err
+	}
+
+	gvk, err := o.RESTMapper.KindFor(gvr)
+	if err != nil {
+		return err
+	}
+
+	// TODO: remove after tekton results migration to V1 APIs
+	gvk.Version = "v1beta1"
+
+	v, k := gvk.ToAPIVersionAndKind()
+
+	opts := &action.Options{
+		ListOptions: metav1.ListOptions{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       k,
+				APIVersion: v,
+			},
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      o.Name,
+			Namespace: o.Namespace,
+			UID:       types.UID(o.UID),
+		},
+	}
+
+	ul, err := action.List(o.Client, opts)
+	if err != nil {
+		return err
+	}
+
+	if len(ul.Items) == 0 {
+		return printers.WriteEscaped(o.IOStreams.Out, fmt.Sprintf("No %s found", gvk.Kind))
+	}
+	run := &ul.Items[0]
+
+	switch gvk.Kind {
+	case "PipelineRun":
+		trs, err := action.TaskRuns(o.Client, run)
+		if err != nil {
+			return err
+		}
+		return printer.DescribePipelineRun(o.IOStreams.Out, run, trs)
+	case "TaskRun":
+		return printer.DescribeTaskRun(o.IOStreams.Out, run)
+	}
+	return fmt.Errorf("describe is not supported for %s", gvk.Kind)
+}
@@ -0,0 +1,211 @@
+package explain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/cel-go/cel"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/query"
+	"github.com/spf13/cobra"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"io"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/explain"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"reflect"
+	"strings"
+)
+
+// records is the scheme of the types of the data of records.
+var records = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(v1beta1.AddToScheme(records))
+}
+
+type explainOptions struct {
+	Resource  string
+	Field     string
+	Recursive bool
+
+	RESTMapper meta.RESTMapper
+
+	IOStreams *genericiooptions.IOStreams
+	Factory   util.Factory
+}
+
+var (
+	explainLong = templates.LongDesc(i18n.T(`
+		Describe the variables and functions which can be used in filters of tekton results.
+
+		Without arguments the variables of records and results and the functions are listed.
+
+		With a resource the fields of the data of its records are listed, the path of a
+		field can be selected like data.status.conditions. Lists and maps are selected
+		the same way as their elements.`))
+
+	explainExample = templates.Examples(`
+		# List the variables and functions of filters
+		kubectl tekton explain-filter
+
+		# List the fields of the data of PipelineRun records
+		kubectl tekton explain-filter pr
+
+		# List the fields of the conditions of TaskRun records
+		kubectl tekton explain-filter tr data.status.conditions
+
+		# List all fields of the status of PipelineRun records
+		kubectl tekton explain-filter pr data.status --recursive`)
+)
+
+func Command(s *genericiooptions.IOStreams, f util.Factory) *cobra.Command {
+	o := &explainOptions{
+		IOStreams: s,
+		Factory:   f,
+	}
+
+	c := &cobra.Command{
+		Use:     "explain-filter [RESOURCE] [FIELD]",
+		Short:   i18n.T("Describe the fields and functions of filters of tekton results"),
+		Long:    explainLong,
+		Example: explainExample,
+		Args:    cobra.MaximumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.Complete(args))
+			util.CheckErr(o.Validate())
+			util.CheckErr(o.Run())
+		},
+	}
+
+	c.Flags().BoolVarP(&o.Recursive, "recursive", "", false, "List the fields of nested objects")
+
+	return c
+}
+
+// Complete completes the required command-line options
+func (o *explainOptions) Complete(args []string) (err error) {
+	o.Field = "data"
+	switch len(args) {
+	case 2:
+		o.Field = args[1]
+		fallthrough
+	case 1:
+		o.Resource = args[0]
+	default:
+		return nil
+	}
+
+	o.RESTMapper, err = o.Factory.ToRESTMapper()
+	return err
+}
+
+// Validate makes sure that provided values for command-line options are valid
+func (o *explainOptions) Validate() error {
+	if o.Field != "data" && !strings.HasPrefix(o.Field, "data.") {
+		return errors.New("field should be a path of the data, e.g. data.status")
+	}
+	return nil
+}
+
+// Run performs the execution of 'explain-filter' sub command
+func (o *explainOptions) Run() error {
+	if o.Resource == "" {
+		return o.reference()
+	}
+
+	gvr, _, err := explain.SplitAndParseResourceRequest(o.Resource, o.RESTMapper)
+	if err != nil {
+		return err
+	}
+
+	gvk, err := o.RESTMapper.KindFor(gvr)
+	if err != nil {
+		return err
+	}
+
+	// TODO: Version override is not required after tekton results migration to V1 APIs
+	gvk.Version = v1beta1.SchemeGroupVersion.Version
+
+	obj, err := records.New(gvk)
+	if err != nil {
+		return fmt.Errorf("no schema found for %s: %w", gvk.Kind, err)
+	}
+
+	t, err := lookup(reflect.TypeOf(obj).Elem(), strings.Split(o.Field, "."))
+	if err != nil {
+		return err
+	}
+
+	w := printers.GetNewTabWriter(o.IOStreams.Out)
+	defer w.Flush()
+
+	fmt.Fprintf(w, "KIND:\t%s\n", gvk.Kind)
+	fmt.Fprintf(w, "VERSION:\t%s\n\n", gvk.GroupVersion())
+	fmt.Fprintf(w, "FIELD:\t%s <%s>\n", o.Field, celType(t))
+	if e := example(o.Field, t); e != "" {
+		fmt.Fprintf(w, "EXAMPLE:\t%s\n", e)
+	}
+
+	if ot := object(t); ot != nil {
+		fmt.Fprintf(w, "\nFIELDS:\n")
+		printFields(w, ot, 1, o.Recursive, map[reflect.Type]bool{})
+	}
+	return nil
+}
+
+// printFields prints the fields of an object, nested objects are printed
+// once on each path to avoid recursive types.
+func printFields(w io.Writer, t reflect.Type, depth int, recursive bool, seen map[reflect.Type]bool) {
+	seen[t] = true
+	defer delete(seen, t)
+
+	indent := strings.Repeat("  ", depth)
+	for _, f := range fields(t) {
+		fmt.Fprintf(w, "%s%s\t<%s>\n", indent, f.Name, celType(f.Type))
+		if o := object(f.Type); recursive && o != nil && !seen[o] {
+			printFields(w, o, depth+1, recursive, seen)
+		}
+	}
+}
+
+// typeName returns the name of a type the same way as the fields of data.
+func typeName(t *cel.Type) string {
+	if t.IsExactType(cel.TimestampType) {
+		return "timestamp"
+	}
+	if t.IsExactType(cel.AnyType) {
+		return "dyn"
+	}
+	return t.String()
+}
+
+// reference prints the variables and functions of filters.
+func (o *explainOptions) reference() error {
+	w := printers.GetNewTabWriter(o.IOStreams.Out)
+	defer w.Flush()
+
+	variables := func(title string, vs []query.Variable) {
+		fmt.Fprintf(w, "%s:\n", title)
+		fmt.Fprintln(w, "  NAME\tTYPE\tDESCRIPTION\tEXAMPLE")
+		for _, v := range vs {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", v.Name, typeName(v.Type), v.Description, v.Example)
+		}
+		fmt.Fprintln(w)
+	}
+	variables("RECORD VARIABLES", query.RecordVariables)
+	variables("RESULT VARIABLES", query.ResultVariables)
+
+	fmt.Fprintln(w, "FUNCTIONS:")
+	fmt.Fprintln(w, "  NAME\tSIGNATURE\tDESCRIPTION\tEXAMPLE")
+	for _, f := range query.Functions {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", f.Name, f.Signature, f.Description, f.Example)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Use 'kubectl tekton explain-filter RESOURCE [FIELD]' to list the fields of data.")
+	return nil
+}
@@ -0,0 +1,151 @@
+package explain
+
+import (
+	"encoding/json"
+	"fmt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"reflect"
+	"strings"
+)
+
+var (
+	timeTypes = map[reflect.Type]bool{
+		reflect.TypeOf(metav1.Time{}):       true,
+		reflect.TypeOf(metav1.MicroTime{}):  true,
+		reflect.TypeOf(apis.VolatileTime{}): true,
+	}
+	durationType  = reflect.TypeOf(metav1.Duration{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// field is a field of an object with its name in the json encoding.
+type field struct {
+	Name string
+	Type reflect.Type
+}
+
+// celType returns the type of a field in CEL, objects are dynamic maps in
+// the data of a record.
+func celType(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case timeTypes[t]:
+		return "timestamp"
+	case t == durationType:
+		return "string"
+	case t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType):
+		// Types with a custom encoding, e.g. param values which are strings,
+		// lists or objects.
+		return "dyn"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "uint"
+	case reflect.Float32, reflect.Float64:
+		return "double"
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "bytes"
+		}
+		return "list(" + celType(t.Elem()) + ")"
+	case reflect.Map:
+		return "map(" + celType(t.Key()) + ", " + celType(t.Elem()) + ")"
+	case reflect.Struct:
+		return "object"
+	}
+	return "dyn"
+}
+
+// object returns the object type of a field, the elements of lists and maps
+// are selected the same way as the fields of an object. It returns nil for
+// scalar types.
+func object(t reflect.Type) reflect.Type {
+	for {
+		switch {
+		case timeTypes[t], t == durationType:
+			return nil
+		case t.Kind() == reflect.Pointer, t.Kind() == reflect.Slice, t.Kind() == reflect.Map:
+			t = t.Elem()
+		case t.Kind() == reflect.Struct && celType(t) == "object":
+			return t
+		default:
+			return nil
+		}
+	}
+}
+
+// fields returns the fields of an object, the fields of inlined structs are
+// merged into it.
+func fields(t reflect.Type) []field {
+	var fs []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch {
+		case name == "-":
+			continue
+		case name == "" && (f.Anonymous || strings.Contains(opts, "inline")):
+			if o := object(f.Type); o != nil {
+				fs = append(fs, fields(o)...)
+			}
+			continue
+		case name == "":
+			name = f.Name
+		}
+		fs = append(fs, field{Name: name, Type: f.Type})
+	}
+	return fs
+}
+
+// lookup returns the field of an object selected by its path, the first
+// element of the path is the object itself.
+func lookup(t reflect.Type, path []string) (reflect.Type, error) {
+	for i := 1; i < len(path); i++ {
+		o := object(t)
+		if o == nil {
+			return nil, fmt.Errorf("field %q does not exist, %s is a %s", path[i], strings.Join(path[:i], "."), celType(t))
+		}
+		var found bool
+		for _, f := range fields(o) {
+			if f.Name == path[i] {
+				t, found = f.Type, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("field %q does not exist", strings.Join(path[:i+1], "."))
+		}
+	}
+	return t, nil
+}
+
+// example returns a filter on the field at the path.
+func example(path string, t reflect.Type) string {
+	switch ct := celType(t); {
+	case ct == "string":
+		return fmt.Sprintf(`%s == "value"`, path)
+	case ct == "timestamp":
+		return fmt.Sprintf(`%s > timestamp("2023-10-17T00:00:00Z")`, path)
+	case ct == "bool":
+		return fmt.Sprintf(`%s == true`, path)
+	case ct == "int", ct == "uint", ct == "double":
+		return fmt.Sprintf(`%s > 0`, path)
+	case strings.HasPrefix(ct, "list("):
+		return fmt.Sprintf(`%s.contains("value")`, path)
+	case strings.HasPrefix(ct, "map("):
+		return fmt.Sprintf(`%s["key"] == "value"`, path)
+	}
+	return ""
+}
@@ -0,0 +1,247 @@
+package find
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sayan-biswas/kubectl-tekton/internal/printer"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/config"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/query"
+	"github.com/spf13/cobra"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/explain"
+	"k8s.io/kubectl/pkg/scheme"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"strings"
+)
+
+const maxPageSize = 100
+
+// kinds are searched when no resource is specified.
+var kinds = []string{"PipelineRun", "TaskRun"}
+
+type findOptions struct {
+	PrintFlags  *genericclioptions.PrintFlags
+	PrintObject printers.ResourcePrinterFunc
+
+	Namespace     string
+	AllNamespaces bool
+	Resource      string
+	Results       []string
+	Params        []string
+	Limit         int32
+	All           bool
+
+	Client     client.Client
+	RESTMapper meta.RESTMapper
+
+	IOStreams *genericiooptions.IOStreams
+	Factory   util.Factory
+
+	results, params map[string]string
+}
+
+var (
+	findLong = templates.LongDesc(i18n.T(`
+		Find archived PipelineRuns and TaskRuns by the values of their results or params.
+
+		Results and params are given as NAME=VALUE, or as NAME to find the runs which
+		have the result or param with any value. The runs are printed with the
+		PipelineRun they belong to and the results and params which matched, to trace
+		an artifact back to the run which built it.`))
+
+	findExample = templates.Examples(`
+		# Find the runs which produced an image digest
+		kubectl tekton find --result IMAGE_DIGEST=sha256:6e1a4a1b5e0c -A
+
+		# Find the TaskRuns which cloned a commit
+		kubectl tekton find tr --result commit=4ad1c2e -n default
+
+		# Find the PipelineRuns which were started with a param
+		kubectl tekton find pr --param git-url=https://github.com/tektoncd/results -n default`)
+)
+
+func Command(s *genericiooptions.IOStreams, f util.Factory) *cobra.Command {
+	o := &findOptions{
+		PrintFlags: genericclioptions.
+			NewPrintFlags("").
+			WithTypeSetter(scheme.Scheme).
+			WithDefaultOutput("yaml"),
+		IOStreams: s,
+		Factory:   f,
+	}
+
+	c := &cobra.Command{
+		Use:     "find [RESOURCE]",
+		Short:   i18n.T("Find runs from tekton results by their results or params"),
+		Long:    findLong,
+		Example: findExample,
+		Args:    cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.Complete(args))
+			util.CheckErr(o.Validate())
+			util.CheckErr(o.Run())
+		},
+	}
+
+	o.PrintFlags.AddFlags(c)
+	c.Flags().StringArrayVarP(&o.Results, "result", "", nil, "Find runs with the result, as NAME=VALUE or NAME")
+	c.Flags().StringArrayVarP(&o.Params, "param", "", nil, "Find runs with the param, as NAME=VALUE or NAME")
+	c.Flags().Int32VarP(&o.Limit, "limit", "", 10, "Limit number of runs")
+	c.Flags().BoolVarP(&o.All, "all", "", false, "List all runs which are found")
+	c.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", false, "Find runs across all namespaces")
+
+	return c
+}
+
+// Complete completes the required command-line options
+func (o *findOptions) Complete(args []string) (err error) {
+	printer, err := o.PrintFlags.ToPrinter()
+	if err != nil {
+		return err
+	}
+	o.PrintObject = printer.PrintObj
+
+	o.Namespace, _, err = o.Factory.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+	if o.AllNamespaces {
+		o.Namespace = ""
+	}
+
+	o.RESTMapper, err = o.Factory.ToRESTMapper()
+	if err != nil {
+		return err
+	}
+
+	c, err := config.NewConfig()
+	if err != nil {
+		return err
+	}
+
+	o.Client, err = client.NewClient(c.Get())
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		o.Resource = args[0]
+	}
+
+	o.results = parseValues(o.Results)
+	o.params = parseValues(o.Params)
+
+	return nil
+}
+
+// Validate makes sure that provided values for command-line options are valid
+func (o *findOptions) Validate() error {
+	if o.Namespace == "" && !o.AllNamespaces {
+		return errors.New("namespace must be specified")
+	}
+	if len(o.results) == 0 && len(o.params) == 0 {
+		return errors.New("at least one --result or --param must be specified")
+	}
+	if o.Limit < 1 {
+		return errors.New("limit should be a positive number")
+	}
+	return nil
+}
+
+// Run performs the execution of 'find' sub command
+func (o *findOptions) Run() error {
+	opts := &action.Options{
+		ListOptions: metav1.ListOptions{
+			Limit: maxPageSize,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: o.Namespace,
+		},
+		Results: o.results,
+		Params:  o.params,
+	}
+
+	if o.Resource != "" {
+		gvr, _, err := explain.SplitAndParseResourceRequest(o.Resource, o.RESTMapper)
+		if err != nil {
+			return err
+		}
+		gvk, err := o.RESTMapper.KindFor(gvr)
+		if err != nil {
+			return err
+		}
+		// TODO: Version override is not required after tekton results migration to V1 APIs
+		gvk.Version = "v1beta1"
+		opts.APIVersion, opts.Kind = gvk.ToAPIVersionAndKind()
+	} else {
+		// TODO: Version is not required after tekton results migration to V1 APIs
+		dataTypes := make([]string, len(kinds))
+		for i, k := range kinds {
+			dataTypes[i] = pipeline.GroupName + "/v1beta1." + k
+		}
+		opts.Filter = query.In(query.Field("data_type"), query.Strings(dataTypes...)...).String()
+	}
+
+	var items []unstructured.Unstructured
+	pager := action.NewPager(o.Client, opts)
+	for pager.More() && (o.All || len(items) < int(o.Limit)) {
+		ul, err := pager.Next()
+		if err != nil {
+			return err
+		}
+		items = append(items, ul.Items...)
+	}
+	if !o.All && len(items) > int(o.Limit) {
+		items = items[:o.Limit]
+		fmt.Fprintln(o.IOStreams.ErrOut, "More runs found, use --all to list all runs")
+	}
+
+	if o.PrintFlags.OutputFlagSpecified() {
+		list := &unstructured.UnstructuredList{
+			Object: map[string]interface{}{
+				"kind":       "List",
+				"apiVersion": "v1",
+				"metadata":   map[string]interface{}{},
+			},
+			Items: items,
+		}
+		return o.PrintObject(list, o.IOStreams.Out)
+	}
+
+	l := &printer.List{Items: make([]printer.Item, len(items))}
+	for i := range items {
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(items[i].Object, &l.Items[i])
+		if err != nil {
+			return err
+		}
+		l.Items[i].Match = action.Matches(&items[i], o.results, o.params)
+	}
+
+	return printer.PrintMatches(o.IOStreams.Out, l, &printer.ListOptions{
+		AllNamespaces: o.AllNamespaces,
+	})
+}
+
+// parseValues parses NAME=VALUE pairs, the value is empty for a NAME.
+func parseValues(s []string) map[string]string {
+	if len(s) == 0 {
+		return nil
+	}
+	m := map[string]string{}
+	for _, v := range s {
+		name, value, _ := strings.Cut(v, "=")
+		m[strings.TrimSpace(name)] = value
+	}
+	return m
+}
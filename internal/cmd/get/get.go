@@ -8,19 +8,31 @@ import (
 	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
 	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
 	"github.com/sayan-biswas/kubectl-tekton/internal/results/config"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/query"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 	"k8s.io/cli-runtime/pkg/printers"
+	cmdget "k8s.io/kubectl/pkg/cmd/get"
 	"k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/explain"
 	"k8s.io/kubectl/pkg/scheme"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
+	"k8s.io/kubectl/pkg/util/term"
+	"strings"
+	"time"
+)
+
+const (
+	minPageSize = 5
+	maxPageSize = 100
 )
 
 type getOptions struct {
@@ -29,8 +41,10 @@ type getOptions struct {
 	ToPrinter   func(*meta.RESTMapping, *bool, bool, bool) (printers.ResourcePrinterFunc, error)
 
 	Namespace       string
+	AllNamespaces   bool
 	Resource        string
 	Name            string
+	Reference       string
 	UID             string
 	Limit           int32
 	Labels          string
@@ -38,12 +52,26 @@ type getOptions struct {
 	Finalizers      string
 	OwnerReferences string
 	Filter          string
+	Since           string
+	Until           string
+	CreatedAfter    string
+	Status          string
+	SortBy          string
+	Runs            action.RunFilter
+	Live            bool
+	All             bool
+	Wide            bool
+	Tree            bool
 
 	Client     client.Client
 	RESTMapper meta.RESTMapper
 
 	IOStreams *genericiooptions.IOStreams
 	Factory   util.Factory
+
+	since, until, createdAfter time.Time
+	selector                   labels.Selector
+	order                      *sortBy
 }
 
 var (
@@ -55,7 +83,70 @@ var (
 		kubectl tekton get pr -n default
 
 		# Get resources by specifying name
-		kubectl tekton get pr test-pr -n default`)
+		kubectl tekton get pr test-pr -n default
+
+		# Get resources from all namespaces
+		kubectl tekton get pr -A
+
+		# Get all resources as a JSON list
+		kubectl tekton get pr -n default --all -o json
+
+		# Get runs which were started in the last 12 hours
+		kubectl tekton get pr -n default --since 12h
+
+		# Get runs which were started and completed in a time range
+		kubectl tekton get pr -n default --since 2023-10-16T18:00:00Z --until 2023-10-17T06:00:00Z
+
+		# Get runs with labels matching a selector
+		kubectl tekton get pr -n default --selector 'app=build,env notin (prod),!canary'
+
+		# Get runs which failed in the last 12 hours
+		kubectl tekton get pr -n default --status Failed --since 12h
+
+		# Get the TaskRuns of a PipelineRun
+		kubectl tekton get tr -n default --parent-run test-pr
+
+		# Get the runs of a pull request of a Pipelines-as-Code repository
+		kubectl tekton get pr -n default --repo my-repo --pull-request 42
+
+		# Get the oldest runs first
+		kubectl tekton get pr -n default --sort-by create_time
+
+		# Get the longest runs first
+		kubectl tekton get pr -n default --all --sort-by "duration desc"
+
+		# Get resources with additional columns
+		kubectl tekton get pr -n default -o wide
+
+		# Get resources with custom columns
+		kubectl tekton get tr -n default -o custom-columns=NAME:.metadata.name,POD:.status.podName
+
+		# Get resources with a go template
+		kubectl tekton get pr -n default -o go-template='{{range .items}}{{.metadata.name}}{{"\n"}}{{end}}'
+
+		# Get all resources without pagination
+		kubectl tekton get pr -n default --all
+
+		# Get resources from tekton results server and the cluster
+		kubectl tekton get pr -n default --live
+
+		# Get the results with the summary of their PipelineRun or TaskRun
+		kubectl tekton get results -n default
+
+		# Get the results which failed
+		kubectl tekton get results -n default --filter 'summary.status == FAILURE'
+
+		# Get the records of a result, e.g. the PipelineRun, TaskRuns and logs
+		kubectl tekton get records 3d8c6c52-6a0b-4b7a-a4ab-2f4b8b3b3a5e -n default
+
+		# Get the results with their records as a tree
+		kubectl tekton get results -n default --tree
+
+		# Get a record by its name, e.g. of the results.tekton.dev/record annotation
+		kubectl tekton get default/results/3d8c6c52-6a0b-4b7a-a4ab-2f4b8b3b3a5e/records/3d8c6c52-6a0b-4b7a-a4ab-2f4b8b3b3a5e
+
+		# Get the run of a result by the UID of the result
+		kubectl tekton get 3d8c6c52-6a0b-4b7a-a4ab-2f4b8b3b3a5e -n default`)
 )
 
 func Command(s *genericiooptions.IOStreams, f util.Factory) *cobra.Command {
@@ -83,30 +174,61 @@ func Command(s *genericiooptions.IOStreams, f util.Factory) *cobra.Command {
 
 	o.PrintFlags.AddFlags(c)
 
-	c.Flags().Int32VarP(&o.Limit, "limit", "", 10, "Limit number or resource")
+	c.Flags().Int32VarP(&o.Limit, "limit", "", 10, "Limit number of resources per page")
+	c.Flags().BoolVarP(&o.All, "all", "", false, "List all resources without pagination")
+	c.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", false, "List resources across all namespaces")
 	c.Flags().StringVarP(&o.UID, "uid", "", "", "UID to select unique item")
-	c.Flags().StringVarP(&o.Labels, "selector", "", "", "Filter items by labels")
-	c.Flags().StringVarP(&o.Labels, "labels", "", "", "Filter items by labels")
+	c.Flags().StringVarP(&o.Labels, "selector", "", "", "Filter items by a label selector, supports '=', '==', '!=', 'in', 'notin' and '!key'")
+	c.Flags().StringVarP(&o.Labels, "labels", "", "", "Filter items by a label selector, supports '=', '==', '!=', 'in', 'notin' and '!key'")
 	c.Flags().StringVarP(&o.Annotations, "annotations", "", "", "Filter items by annotations")
 	c.Flags().StringVarP(&o.Finalizers, "finalizers", "", "", "Filter items by finalizers")
 	c.Flags().StringVarP(&o.OwnerReferences, "owner-references", "", "", "Filter items by OwnerReferences")
 	c.Flags().StringVarP(&o.Filter, "filter", "", "", "Use a raw filter string")
+	c.Flags().StringVarP(&o.Since, "since", "", "", "Only list runs started after this duration ago or RFC3339 timestamp")
+	c.Flags().StringVarP(&o.Until, "until", "", "", "Only list runs completed before this duration ago or RFC3339 timestamp")
+	c.Flags().StringVarP(&o.CreatedAfter, "created-after", "", "", "Only list runs created after this duration ago or RFC3339 timestamp")
+	c.Flags().StringVarP(&o.Status, "status", "", "", "Only list runs with the status, one of "+strings.Join(action.Statuses, "|"))
+	c.Flags().StringVarP(&o.Runs.Pipeline, "pipeline", "", "", "Only list runs of the pipeline")
+	c.Flags().StringVarP(&o.Runs.Task, "task", "", "", "Only list runs of the task")
+	c.Flags().StringVarP(&o.Runs.ParentRun, "parent-run", "", "", "Only list TaskRuns of the PipelineRun")
+	c.Flags().StringVarP(&o.Runs.Repository, "repo", "", "", "Only list runs of the Pipelines-as-Code repository")
+	c.Flags().StringVarP(&o.Runs.SHA, "sha", "", "", "Only list runs of the Pipelines-as-Code commit")
+	c.Flags().StringVarP(&o.Runs.PullRequest, "pull-request", "", "", "Only list runs of the Pipelines-as-Code pull request")
+	c.Flags().StringVarP(&o.SortBy, "sort-by", "", "", "Sort by create_time, update_time, duration or a JSONPath expression, followed by asc or desc")
+	c.Flags().BoolVarP(&o.Live, "live", "", false, "Include resources from the cluster which are not archived yet")
+	c.Flags().BoolVarP(&o.Tree, "tree", "", false, "Print results or records as a tree of the results and their records")
 
 	return c
 }
 
 // Complete completes the required command-line options
 func (o *getOptions) Complete(args []string) (err error) {
-	printer, err := o.PrintFlags.ToPrinter()
-	if err != nil {
-		return err
+	// Formats for tables are not supported by the print flags, wide is
+	// printed by the list printer and custom columns by the kubectl printer.
+	switch format := *o.PrintFlags.OutputFormat; {
+	case format == "wide":
+		o.Wide = true
+	case strings.HasPrefix(format, "custom-columns"):
+		printer, err := cmdget.NewCustomColumnsPrintFlags().ToPrinter(format)
+		if err != nil {
+			return err
+		}
+		o.PrintObject = printer.PrintObj
+	default:
+		printer, err := o.PrintFlags.ToPrinter()
+		if err != nil {
+			return err
+		}
+		o.PrintObject = printer.PrintObj
 	}
-	o.PrintObject = printer.PrintObj
 
 	o.Namespace, _, err = o.Factory.ToRawKubeConfigLoader().Namespace()
 	if err != nil {
 		return err
 	}
+	if o.AllNamespaces {
+		o.Namespace = ""
+	}
 
 	o.RESTMapper, err = o.Factory.ToRESTMapper()
 	if err != nil {
@@ -125,7 +247,11 @@ func (o *getOptions) Complete(args []string) (err error) {
 
 	switch len(args) {
 	case 1:
-		o.Resource = args[0]
+		if action.IsReference(args[0]) {
+			o.Reference = args[0]
+		} else {
+			o.Resource = args[0]
+		}
 	case 2:
 		o.Resource = args[0]
 		o.Name = args[1]
@@ -133,26 +259,70 @@ func (o *getOptions) Complete(args []string) (err error) {
 		return errors.New("invalid arguments, should of type RESOURCE NAME")
 	}
 
+	if o.selector, err = helper.ParseLabels(o.Labels); err != nil {
+		return fmt.Errorf("invalid --selector: %w", err)
+	}
+	if o.SortBy != "" {
+		if o.order, err = parseSortBy(o.SortBy); err != nil {
+			return fmt.Errorf("invalid --sort-by: %w", err)
+		}
+	}
+	if o.since, err = helper.ParseTime(o.Since); err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	if o.until, err = helper.ParseTime(o.Until); err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+	if o.createdAfter, err = helper.ParseTime(o.CreatedAfter); err != nil {
+		return fmt.Errorf("invalid --created-after: %w", err)
+	}
+
 	return nil
 }
 
 // Validate makes sure that provided values for command-line options are valid
 func (o *getOptions) Validate() error {
-	if o.Namespace == "" {
+	if o.Namespace == "" && !o.AllNamespaces {
 		return errors.New("namespace must be specified")
 	}
-	if o.PrintFlags.OutputFlagSpecified() && o.Name == "" {
-		return errors.New("resource name is required to print resource definition")
+	if o.Limit < 1 {
+		return errors.New("limit should be a positive number")
 	}
-
-	if o.Limit < 5 || o.Limit > 100 {
-		return errors.New("limit should be between 5 and 100")
+	if !o.since.IsZero() && !o.until.IsZero() && o.until.Before(o.since) {
+		return errors.New("until should be after since")
+	}
+	if o.Live && strings.TrimSpace(o.Filter) != "" {
+		return errors.New("--filter can not be used with --live, runs in the cluster can't be filtered by it")
+	}
+	if strings.TrimSpace(o.Filter) != "" {
+		check := query.Check
+		if o.isResults() {
+			check = query.CheckResults
+		}
+		if err := check(o.Filter); err != nil {
+			return err
+		}
+	}
+	if o.isResults() || o.isRecords() {
+		return o.validateResults()
+	}
+	if o.Tree {
+		return errors.New("--tree can only be used with results and records")
 	}
 	return nil
 }
 
 // Run performs the execution of 'config view' sub command
 func (o *getOptions) Run() error {
+	switch {
+	case o.Reference != "":
+		return o.runReference()
+	case o.isResults():
+		return o.runResults()
+	case o.isRecords():
+		return o.runRecords()
+	}
+
 	gvr, _, err := explain.SplitAndParseResourceRequest(o.Resource, o.RESTMapper)
 	if err != nil {
 		return err
@@ -169,53 +339,236 @@ func (o *getOptions) Run() error {
 	v, k := gvk.ToAPIVersionAndKind()
 
 	opts := &action.Options{
-		Filter: o.Filter,
+		Filter:       o.Filter,
+		CreatedAfter: o.createdAfter,
+		Since:        o.since,
+		Until:        o.until,
+		Status:       o.Status,
+		Selector:     o.selector,
+		Runs:         o.Runs,
 		ListOptions: metav1.ListOptions{
 			TypeMeta: metav1.TypeMeta{
 				Kind:       k,
 				APIVersion: v,
 			},
-			Limit: int64(o.Limit),
+			Limit: pageSize(o.Limit, o.All),
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            o.Name,
 			Namespace:       o.Namespace,
 			UID:             types.UID(o.UID),
-			Labels:          helper.ParseLabels(o.Labels),
 			Annotations:     helper.ParseAnnotations(o.Annotations),
 			Finalizers:      helper.ParseFinalizers(o.Finalizers),
 			OwnerReferences: helper.ParseOwnerReferences(o.OwnerReferences),
 		},
 	}
+	if o.order != nil && o.order.server() {
+		opts.OrderBy = o.order.orderBy()
+	}
 
-	for nextPage := true; nextPage; {
-		ul, err := action.List(o.Client, opts)
+	var live map[types.UID]*unstructured.Unstructured
+	if o.Live {
+		live, err = o.liveRuns(gvk.GroupKind(), opts)
 		if err != nil {
 			return err
 		}
+	}
 
-		if o.PrintFlags.OutputFlagSpecified() && len(ul.Items) > 0 {
-			return o.PrintObject(ul.Items[0].DeepCopyObject(), o.IOStreams.Out)
-		}
-		l := new(printer.List)
-		err = runtime.DefaultUnstructuredConverter.FromUnstructured(ul.UnstructuredContent(), l)
+	// Pages are only prompted for on a terminal, otherwise the first page is
+	// printed unless all pages are requested.
+	interactive := !o.All && term.IsTerminal(o.IOStreams.In) && term.IsTerminal(o.IOStreams.Out)
+
+	pager := action.NewPager(o.Client, opts)
+	if o.order != nil && !o.order.server() {
+		return o.printSorted(pager, live)
+	}
+	if o.PrintFlags.OutputFlagSpecified() && !o.Wide {
+		return o.printObjects(pager, live)
+	}
+
+	buffer := &unstructured.UnstructuredList{Object: map[string]interface{}{}}
+	for first := true; ; first = false {
+		ul, more, err := o.nextPage(pager, buffer)
 		if err != nil {
 			return err
 		}
 
-		err = printer.PrintList(o.IOStreams.Out, l)
+		var sources []string
+		if o.Live {
+			sources = merge(ul, live, first)
+		}
+
+		if first || len(ul.Items) > 0 {
+			if err := o.printList(ul, sources, o.All && !first); err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case !more:
+			return nil
+		case o.All:
+			continue
+		case !interactive:
+			fmt.Fprintln(o.IOStreams.ErrOut, "More items available, use --all to list all items")
+			return nil
+		}
+
+		fmt.Fprint(o.IOStreams.ErrOut, "\nNext Page: Press enter to continue!")
+		fmt.Fscanln(o.IOStreams.In)
+	}
+}
+
+// printList prints the items with the list printer.
+func (o *getOptions) printList(ul *unstructured.UnstructuredList, sources []string, noHeaders bool) error {
+	l := new(printer.List)
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(ul.UnstructuredContent(), l)
+	if err != nil {
+		return err
+	}
+	for i, s := range sources {
+		l.Items[i].Source = s
+	}
+
+	return printer.PrintList(o.IOStreams.Out, l, &printer.ListOptions{
+		AllNamespaces: o.AllNamespaces,
+		NoHeaders:     noHeaders,
+		ShowSource:    o.Live,
+		Wide:          o.Wide,
+	})
+}
+
+// printSorted prints the first --limit items of all pages sorted by a field
+// which is not sorted by tekton results, or all items with --all.
+func (o *getOptions) printSorted(p *action.Pager, live map[types.UID]*unstructured.Unstructured) error {
+	ul := &unstructured.UnstructuredList{Object: map[string]interface{}{}}
+	for p.More() {
+		l, err := p.Next()
 		if err != nil {
 			return err
 		}
+		ul.Object = l.Object
+		ul.Items = append(ul.Items, l.Items...)
+	}
 
-		opts.ListOptions.Continue = l.NextPageToken
-		nextPage = len(l.NextPageToken) > 0
+	var sources []string
+	if o.Live {
+		sources = merge(ul, live, true)
+	}
+	o.order.sort(ul.Items, sources)
+
+	more := !o.All && len(ul.Items) > int(o.Limit)
+	if more {
+		ul.Items = ul.Items[:o.Limit]
+		if sources != nil {
+			sources = sources[:o.Limit]
+		}
+	}
 
-		if nextPage {
-			fmt.Println("\nNext Page: Press any key to continue!")
-			fmt.Scanln()
+	var err error
+	if o.PrintFlags.OutputFlagSpecified() && !o.Wide {
+		list := newList()
+		list.Items = ul.Items
+		if o.Name != "" && len(list.Items) > 0 {
+			err = o.PrintObject(list.Items[0].DeepCopyObject(), o.IOStreams.Out)
+		} else {
+			err = o.PrintObject(list, o.IOStreams.Out)
 		}
+	} else {
+		err = o.printList(ul, sources, false)
+	}
+	if err != nil {
+		return err
 	}
 
+	if more {
+		fmt.Fprintln(o.IOStreams.ErrOut, "More items available, use --all to list all items")
+	}
 	return nil
 }
+
+// newList returns an empty List of the output formats.
+func newList() *unstructured.UnstructuredList {
+	return &unstructured.UnstructuredList{
+		Object: map[string]interface{}{
+			"kind":       "List",
+			"apiVersion": "v1",
+			"metadata":   map[string]interface{}{},
+		},
+	}
+}
+
+// printObjects prints the items with the output format as a List, of the
+// first page or of all pages with --all. If a name is specified only the last
+// updated item is printed.
+func (o *getOptions) printObjects(p *action.Pager, live map[types.UID]*unstructured.Unstructured) error {
+	list := newList()
+
+	buffer := &unstructured.UnstructuredList{Object: map[string]interface{}{}}
+	for first := true; ; first = false {
+		ul, more, err := o.nextPage(p, buffer)
+		if err != nil {
+			return err
+		}
+		if o.Live {
+			merge(ul, live, first)
+		}
+		list.Items = append(list.Items, ul.Items...)
+
+		if o.Name != "" && len(list.Items) > 0 {
+			return o.PrintObject(list.Items[0].DeepCopyObject(), o.IOStreams.Out)
+		}
+		if !more {
+			break
+		}
+		if !o.All {
+			fmt.Fprintln(o.IOStreams.ErrOut, "More items available, use --all to list all items")
+			break
+		}
+	}
+
+	return o.PrintObject(list, o.IOStreams.Out)
+}
+
+// nextPage returns the next page of at most --limit items, or the next page
+// from the server with --all, and reports if there are more items. Items of
+// fetched pages are kept in the buffer with the object of the last page, which
+// pages served from the buffer are printed with.
+func (o *getOptions) nextPage(p *action.Pager, buffer *unstructured.UnstructuredList) (*unstructured.UnstructuredList, bool, error) {
+	size := int(o.Limit)
+	if o.All {
+		size = 1
+	}
+
+	for p.More() && len(buffer.Items) < size {
+		l, err := p.Next()
+		if err != nil {
+			return nil, false, err
+		}
+		buffer.Object = l.Object
+		buffer.Items = append(buffer.Items, l.Items...)
+	}
+
+	n := len(buffer.Items)
+	if !o.All && n > int(o.Limit) {
+		n = int(o.Limit)
+	}
+	ul := &unstructured.UnstructuredList{
+		Object: buffer.Object,
+		Items:  buffer.Items[:n],
+	}
+	buffer.Items = buffer.Items[n:]
+	return ul, len(buffer.Items) > 0 || p.More(), nil
+}
+
+// pageSize returns the page size to request from the server, limits above
+// maxPageSize are split into multiple pages.
+func pageSize(limit int32, all bool) int64 {
+	switch {
+	case all || limit > maxPageSize:
+		return maxPageSize
+	case limit < minPageSize:
+		return minPageSize
+	}
+	return int64(limit)
+}
@@ -0,0 +1,86 @@
+package get
+
+import (
+	"context"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
+	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sort"
+)
+
+const (
+	sourceLive     = "live"
+	sourceArchived = "archived"
+	sourceBoth     = "both"
+)
+
+// liveRuns lists the runs in the cluster which match the options, the same
+// way tekton results is queried.
+func (o *getOptions) liveRuns(gk schema.GroupKind, opts *action.Options) (map[types.UID]*unstructured.Unstructured, error) {
+	dc, err := o.Factory.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	m, err := o.RESTMapper.RESTMapping(gk)
+	if meta.IsNoMatchError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ul, err := dc.Resource(m.Resource).Namespace(o.Namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: o.selector.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	live := map[types.UID]*unstructured.Unstructured{}
+	for i := range ul.Items {
+		u := &ul.Items[i]
+		if opts.MatchRun(u) {
+			live[u.GetUID()] = u
+		}
+	}
+	return live, nil
+}
+
+// merge replaces the archived items with the live objects of the same UID and
+// returns the source of every item. On the first page the live objects which
+// are not archived yet are added in front of the list, newest first.
+func merge(ul *unstructured.UnstructuredList, live map[types.UID]*unstructured.Unstructured, first bool) []string {
+	var items []unstructured.Unstructured
+	var sources []string
+
+	if first {
+		for _, u := range live {
+			if _, archived := u.GetAnnotations()[annotation.Record]; !archived {
+				items = append(items, *u)
+			}
+		}
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].GetCreationTimestamp().After(items[j].GetCreationTimestamp().Time)
+		})
+		for range items {
+			sources = append(sources, sourceLive)
+		}
+	}
+
+	for _, u := range ul.Items {
+		if l, exists := live[u.GetUID()]; exists {
+			items = append(items, *l)
+			sources = append(sources, sourceBoth)
+			continue
+		}
+		items = append(items, u)
+		sources = append(sources, sourceArchived)
+	}
+
+	ul.Items = items
+	return sources
+}
@@ -0,0 +1,244 @@
+package get
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/sayan-biswas/kubectl-tekton/internal/printer"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
+	results "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// resultsAPIVersion is the API version of results and records printed with
+// an output format.
+const resultsAPIVersion = "results.tekton.dev/v1alpha2"
+
+func (o *getOptions) isResults() bool {
+	return o.Resource == "results" || o.Resource == "result"
+}
+
+func (o *getOptions) isRecords() bool {
+	return o.Resource == "records" || o.Resource == "record"
+}
+
+// validateResults makes sure only the options which tekton results supports
+// for results and records are used.
+func (o *getOptions) validateResults() error {
+	if o.isRecords() && o.Name == "" {
+		return errors.New("a result must be specified, e.g. get records RESULT")
+	}
+	if o.Tree && o.PrintFlags.OutputFlagSpecified() {
+		return errors.New("--tree can not be used with --output")
+	}
+	if o.order != nil && !o.order.server() {
+		return errors.New("results and records can only be sorted by create_time or update_time")
+	}
+	if o.Since != "" || o.Until != "" || o.CreatedAfter != "" || o.Status != "" || o.Labels != "" ||
+		o.Annotations != "" || o.Finalizers != "" || o.OwnerReferences != "" || o.Runs != (action.RunFilter{}) ||
+		o.UID != "" || o.Live {
+		return errors.New("results and records can only be filtered with --filter")
+	}
+	return nil
+}
+
+// resultOptions returns the options to list results or records of a page of
+// --limit items.
+func (o *getOptions) resultOptions() *action.Options {
+	opts := &action.Options{
+		Filter: o.Filter,
+		ListOptions: metav1.ListOptions{
+			Limit: pageSize(o.Limit, o.All),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: o.Namespace,
+		},
+	}
+	if o.order != nil {
+		opts.OrderBy = o.order.orderBy()
+	}
+	return opts
+}
+
+// runResults lists the results with the summary of their main record, or as a
+// tree with their records.
+func (o *getOptions) runResults() error {
+	opts := o.resultOptions()
+	opts.Name = o.Name
+
+	rs, more, err := collect(int(o.Limit), o.All, func(token string) ([]*results.Result, string, error) {
+		opts.Continue = token
+		rl, err := action.Results(o.Client, opts)
+		return rl.GetResults(), rl.GetNextPageToken(), err
+	})
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case o.Tree:
+		var ts []printer.Tree
+		for _, r := range rs {
+			records, err := action.AllRecords(o.Client, &action.Options{
+				Result:  r.GetName(),
+				OrderBy: "create_time asc",
+			})
+			if err != nil {
+				return err
+			}
+			ts = append(ts, tree(r, records))
+		}
+		err = printer.PrintTree(o.IOStreams.Out, ts)
+	case o.PrintFlags.OutputFlagSpecified() && !o.Wide:
+		err = printMessages(o, "Result", rs)
+	default:
+		var items []printer.Result
+		for _, r := range rs {
+			items = append(items, printer.NewResult(r))
+		}
+		err = printer.PrintResults(o.IOStreams.Out, items, &printer.ListOptions{
+			AllNamespaces: o.AllNamespaces,
+			Wide:          o.Wide,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if more {
+		fmt.Fprintln(o.IOStreams.ErrOut, "More items available, use --all to list all items")
+	}
+	return nil
+}
+
+// runRecords lists the records of a result of any type, e.g. the PipelineRun,
+// TaskRuns and logs, or prints them as a tree below the result.
+func (o *getOptions) runRecords() error {
+	opts := o.resultOptions()
+	if opts.Namespace == "" {
+		opts.Namespace = "-"
+	}
+	opts.Result = action.ResultName(opts.Namespace, o.Name)
+	if o.Tree && o.order == nil {
+		opts.OrderBy = "create_time asc"
+	}
+
+	rs, more, err := collect(int(o.Limit), o.All, func(token string) ([]*results.Record, string, error) {
+		opts.Continue = token
+		rl, err := action.Records(o.Client, opts)
+		return rl.GetRecords(), rl.GetNextPageToken(), err
+	})
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case o.Tree:
+		var r *results.Result
+		if r, err = action.Result(o.Client, opts.Result); err != nil {
+			return err
+		}
+		err = printer.PrintTree(o.IOStreams.Out, []printer.Tree{tree(r, rs)})
+	case o.PrintFlags.OutputFlagSpecified() && !o.Wide:
+		err = printMessages(o, "Record", rs)
+	default:
+		var items []printer.Record
+		for _, r := range rs {
+			items = append(items, printer.NewRecord(r))
+		}
+		err = printer.PrintRecords(o.IOStreams.Out, items, &printer.ListOptions{})
+	}
+	if err != nil {
+		return err
+	}
+
+	if more {
+		fmt.Fprintln(o.IOStreams.ErrOut, "More items available, use --all to list all items")
+	}
+	return nil
+}
+
+// collect lists the items of the pages until there are --limit items, or of
+// all pages, and reports if there are more items.
+func collect[T any](limit int, all bool, next func(token string) ([]T, string, error)) ([]T, bool, error) {
+	var items []T
+	var token string
+	for {
+		page, t, err := next(token)
+		if err != nil {
+			return nil, false, err
+		}
+		items = append(items, page...)
+		token = t
+
+		switch {
+		case !all && len(items) > limit:
+			return items[:limit], true, nil
+		case !all && len(items) == limit:
+			return items, token != "", nil
+		case token == "":
+			return items, false, nil
+		}
+	}
+}
+
+func tree(r *results.Result, records []*results.Record) printer.Tree {
+	t := printer.Tree{Result: printer.NewResult(r)}
+	for _, rec := range records {
+		t.Records = append(t.Records, printer.NewRecord(rec))
+	}
+	return t
+}
+
+// printMessages prints results or records with the output format as a List.
+// The data of records is printed as an object instead of encoded bytes.
+func printMessages[T proto.Message](o *getOptions, kind string, ms []T) error {
+	list := newList()
+	for _, m := range ms {
+		b, err := protojson.Marshal(m)
+		if err != nil {
+			return err
+		}
+		u := &unstructured.Unstructured{}
+		if err := json.Unmarshal(b, &u.Object); err != nil {
+			return err
+		}
+		if r, ok := proto.Message(m).(*results.Record); ok {
+			var data map[string]interface{}
+			if json.Unmarshal(r.GetData().GetValue(), &data) == nil {
+				_ = unstructured.SetNestedField(u.Object, data, "data", "value")
+			}
+		}
+		u.SetAPIVersion(resultsAPIVersion)
+		u.SetKind(kind)
+		list.Items = append(list.Items, *u)
+	}
+	if o.Name != "" && o.isResults() && len(list.Items) > 0 {
+		return o.PrintObject(list.Items[0].DeepCopyObject(), o.IOStreams.Out)
+	}
+	return o.PrintObject(list, o.IOStreams.Out)
+}
+
+// runReference prints the object of a record referenced by its name, the name
+// of its result or a UID. Runs are printed as a list unless an output format
+// is specified, other objects like logs with the default output format.
+func (o *getOptions) runReference() error {
+	u, err := action.Resolve(o.Client, o.Namespace, o.Reference)
+	if err != nil {
+		return err
+	}
+
+	switch u.GetKind() {
+	case "PipelineRun", "TaskRun":
+		if !o.PrintFlags.OutputFlagSpecified() || o.Wide {
+			ul := newList()
+			ul.SetKind(u.GetKind())
+			ul.Items = []unstructured.Unstructured{*u}
+			return o.printList(ul, nil, false)
+		}
+	}
+	return o.PrintObject(u, o.IOStreams.Out)
+}
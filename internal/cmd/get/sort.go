@@ -0,0 +1,133 @@
+package get
+
+import (
+	"fmt"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/jsonpath"
+	cmdget "k8s.io/kubectl/pkg/cmd/get"
+	"sort"
+	"strings"
+	"time"
+)
+
+// sortDuration sorts by the time between the start and the completion of a
+// run, or the current time for running runs.
+const sortDuration = "duration"
+
+// serverFields are sorted by tekton results.
+var serverFields = []string{"create_time", "update_time"}
+
+// sortBy is the field and the order of --sort-by.
+type sortBy struct {
+	field string
+	desc  bool
+}
+
+// parseSortBy parses a field, optionally followed by asc or desc. The field
+// is a server field, duration or a JSONPath expression into the object.
+func parseSortBy(s string) (*sortBy, error) {
+	fs := strings.Fields(s)
+	if len(fs) == 0 || len(fs) > 2 {
+		return nil, fmt.Errorf("invalid sort %q, expected a field followed by asc or desc", s)
+	}
+
+	sb := &sortBy{field: fs[0]}
+	if len(fs) == 2 {
+		switch strings.ToLower(fs[1]) {
+		case "asc":
+		case "desc":
+			sb.desc = true
+		default:
+			return nil, fmt.Errorf("invalid sort order %q, should be asc or desc", fs[1])
+		}
+	}
+
+	if sb.field == sortDuration || sb.server() {
+		return sb, nil
+	}
+	field, err := cmdget.RelaxedJSONPathExpression(sb.field)
+	if err != nil {
+		return nil, err
+	}
+	if err := jsonpath.New("sort").Parse(field); err != nil {
+		return nil, fmt.Errorf("invalid sort field %q: %w", sb.field, err)
+	}
+	sb.field = field
+	return sb, nil
+}
+
+// server reports whether the items are sorted by tekton results.
+func (s *sortBy) server() bool {
+	for _, f := range serverFields {
+		if s.field == f {
+			return true
+		}
+	}
+	return false
+}
+
+// orderBy returns the order of the records in tekton results.
+func (s *sortBy) orderBy() string {
+	if s.desc {
+		return s.field + " desc"
+	}
+	return s.field + " asc"
+}
+
+// sort sorts the items and their sources, items without the field come
+// first in ascending order.
+func (s *sortBy) sort(items []unstructured.Unstructured, sources []string) {
+	var less func(i, j int) bool
+	switch s.field {
+	case sortDuration:
+		ds := make([]time.Duration, len(items))
+		for i := range items {
+			ds[i] = duration(&items[i])
+		}
+		less = func(i, j int) bool { return ds[i] < ds[j] }
+	default:
+		objs := make([]runtime.Object, len(items))
+		for i := range items {
+			objs[i] = &items[i]
+		}
+		less = cmdget.NewRuntimeSort(s.field, objs).Less
+	}
+
+	idx := make([]int, len(items))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		if s.desc {
+			return less(idx[b], idx[a])
+		}
+		return less(idx[a], idx[b])
+	})
+
+	sorted := make([]unstructured.Unstructured, len(items))
+	for i, j := range idx {
+		sorted[i] = items[j]
+	}
+	copy(items, sorted)
+	if len(sources) == len(items) {
+		ss := make([]string, len(sources))
+		for i, j := range idx {
+			ss[i] = sources[j]
+		}
+		copy(sources, ss)
+	}
+}
+
+func duration(u *unstructured.Unstructured) time.Duration {
+	start := action.RunTime(u, "startTime")
+	if start.IsZero() {
+		return 0
+	}
+	end := action.RunTime(u, "completionTime")
+	if end.IsZero() {
+		end = time.Now()
+	}
+	return end.Sub(start)
+}
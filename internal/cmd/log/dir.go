@@ -0,0 +1,173 @@
+package log
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"os"
+	"path/filepath"
+)
+
+const (
+	indexFile   = "index.json"
+	defaultStep = "log"
+)
+
+// index describes the records which were written to the output directory.
+type index struct {
+	Kind     string    `json:"kind"`
+	Name     string    `json:"name"`
+	UID      types.UID `json:"uid"`
+	Task     string    `json:"task,omitempty"`
+	Record   string    `json:"record,omitempty"`
+	Log      string    `json:"log,omitempty"`
+	Source   string    `json:"source,omitempty"`
+	Status   string    `json:"status"`
+	Dir      string    `json:"dir,omitempty"`
+	Files    []string  `json:"files,omitempty"`
+	TaskRuns []*index  `json:"taskRuns,omitempty"`
+}
+
+func newIndex(u *unstructured.Unstructured) *index {
+	return &index{
+		Kind:   u.GetKind(),
+		Name:   u.GetName(),
+		UID:    u.GetUID(),
+		Task:   u.GetLabels()[pipeline.PipelineTaskLabelKey],
+		Record: u.GetAnnotations()[annotation.Record],
+		Log:    u.GetAnnotations()[annotation.Log],
+		Status: conditionReason(u),
+	}
+}
+
+// conditionReason returns the reason of the succeeded condition of a run.
+func conditionReason(u *unstructured.Unstructured) string {
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conditions {
+		if c, ok := c.(map[string]interface{}); ok && c["type"] == "Succeeded" {
+			if reason, ok := c["reason"].(string); ok && reason != "" {
+				return reason
+			}
+		}
+	}
+	return "Unknown"
+}
+
+// dirWriter writes every log line to a file per step, <dir>/<step>.log for
+// TaskRun logs and <dir>/<task>/<step>.log for PipelineRun logs.
+type dirWriter struct {
+	*lineWriter
+	dir   string
+	open  map[string]*os.File
+	names []string
+	last  string
+}
+
+func newDirWriter(dir string) *dirWriter {
+	d := &dirWriter{dir: dir, open: map[string]*os.File{}}
+	d.lineWriter = &lineWriter{fn: d.writeLine}
+	return d
+}
+
+func (d *dirWriter) writeLine(line []byte) error {
+	// Lines without prefix belong to the previous step.
+	task, step, rest, ok := splitPrefix(line)
+	path := d.last
+	switch {
+	case ok:
+		path = filepath.Join(filepath.Base(task), filepath.Base(step)+".log")
+	case len(rest) == 1 && rest[0] == '\n':
+		return nil
+	case path == "":
+		path = defaultStep + ".log"
+	}
+	d.last = path
+
+	f, exists := d.open[path]
+	if !exists {
+		p := filepath.Join(d.dir, path)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return err
+		}
+		var err error
+		f, err = os.Create(p)
+		if err != nil {
+			return err
+		}
+		d.open[path] = f
+		d.names = append(d.names, path)
+	}
+	_, err := f.Write(rest)
+	return err
+}
+
+// Close flushes the partial line and closes all files.
+func (d *dirWriter) Close() error {
+	err := d.Flush()
+	for _, f := range d.open {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// writeDir writes the log of the run, or of its TaskRuns if there are any,
+// to a directory tree under --output-dir, with an index of all records.
+func (o *logOptions) writeDir(run *unstructured.Unstructured, trs []unstructured.Unstructured) error {
+	root := filepath.Join(o.OutputDir, run.GetName())
+	i := newIndex(run)
+
+	if len(trs) == 0 {
+		if err := o.logToDir(run, root, i); err != nil {
+			return err
+		}
+	}
+
+	for _, tr := range trs {
+		if o.Task != "" && o.Task != tr.GetName() && o.Task != tr.GetLabels()[pipeline.PipelineTaskLabelKey] {
+			continue
+		}
+		ti := newIndex(&tr)
+		ti.Dir = tr.GetName()
+		if err := o.logToDir(&tr, filepath.Join(root, ti.Dir), ti); err != nil {
+			return err
+		}
+		i.TaskRuns = append(i.TaskRuns, ti)
+	}
+
+	b, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(root, indexFile), append(b, '\n'), 0o644)
+}
+
+// logToDir writes the log of a run to a file per step in dir and records the
+// files and the source of the log in the index.
+func (o *logOptions) logToDir(u *unstructured.Unstructured, dir string, i *index) error {
+	f := *o.out.filter
+	f.Task = ""
+	d := newDirWriter(dir)
+	w := newFilterWriter(d, &f)
+
+	source, err := o.runLog(u, w)
+	if errors.Is(err, errNoLogs) {
+		return d.Close()
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if cerr := d.Close(); err == nil {
+		err = cerr
+	}
+	i.Source = source
+	i.Files = d.names
+	return err
+}
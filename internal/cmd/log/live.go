@@ -0,0 +1,199 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	sourceArchive = "archive"
+	sourceCluster = "cluster"
+)
+
+// errNoLogs is returned when neither tekton results nor the cluster has logs for a run.
+var errNoLogs = errors.New("no logs found")
+
+// runLog writes the log of a run to w and returns where it was read from. The
+// log is read from tekton results once the log record exists, until then the
+// container logs of the TaskRun pod are read from the cluster.
+func (o *logOptions) runLog(u *unstructured.Unstructured, w io.Writer) (string, error) {
+	if a := u.GetAnnotations()[annotation.Log]; a != "" {
+		err := action.Log(o.Client, &action.Options{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: a,
+			},
+		}, w)
+		if status.Code(err) != codes.NotFound {
+			return sourceArchive, err
+		}
+	}
+	return sourceCluster, o.podLog(u, w)
+}
+
+// podLog writes the step container logs of a TaskRun pod to w, in the same
+// format as the logs stored by the results watcher.
+func (o *logOptions) podLog(tr *unstructured.Unstructured, w io.Writer) error {
+	pod, _, _ := unstructured.NestedString(tr.Object, "status", "podName")
+	steps, _, _ := unstructured.NestedSlice(tr.Object, "status", "steps")
+	if pod == "" || len(steps) == 0 {
+		return errNoLogs
+	}
+
+	cs, err := o.Factory.KubernetesClientSet()
+	if err != nil {
+		return err
+	}
+
+	// The logs of running TaskRuns are followed until the steps complete.
+	follow := action.RunTime(tr, "completionTime").IsZero()
+
+	for _, s := range steps {
+		step, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := step["name"].(string)
+		container, _ := step["container"].(string)
+		if container == "" {
+			continue
+		}
+
+		rc, err := cs.CoreV1().
+			Pods(tr.GetNamespace()).
+			GetLogs(pod, &corev1.PodLogOptions{Container: container, Follow: follow}).
+			Stream(context.Background())
+		if apierrors.IsNotFound(err) {
+			return errNoLogs
+		}
+		if err != nil {
+			return err
+		}
+
+		sw := newStepWriter(w, name)
+		_, err = io.Copy(sw, rc)
+		rc.Close()
+		if err == nil {
+			err = sw.Flush()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// liveRun gets a run from the cluster, nil is returned if it doesn't exist.
+// Without a name the last created run with the labels of the filters is
+// returned.
+func (o *logOptions) liveRun(gk schema.GroupKind) (*unstructured.Unstructured, error) {
+	dc, err := o.Factory.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	m, err := o.RESTMapper.RESTMapping(gk)
+	if meta.IsNoMatchError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if o.Name == "" {
+		ul, err := dc.Resource(m.Resource).Namespace(o.Namespace).List(context.Background(), metav1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(o.Runs.Labels()).String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		var last *unstructured.Unstructured
+		for i := range ul.Items {
+			u := &ul.Items[i]
+			if o.UID != "" && u.GetUID() != types.UID(o.UID) {
+				continue
+			}
+			if last == nil || u.GetCreationTimestamp().After(last.GetCreationTimestamp().Time) {
+				last = u
+			}
+		}
+		return last, nil
+	}
+
+	u, err := dc.Resource(m.Resource).Namespace(o.Namespace).Get(context.Background(), o.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if o.UID != "" && u.GetUID() != types.UID(o.UID) {
+		return nil, nil
+	}
+	return u, nil
+}
+
+// taskRuns returns the archived TaskRuns of a PipelineRun together with the
+// TaskRuns in the cluster which are not archived yet, in pipeline order.
+func (o *logOptions) taskRuns(pr *unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	trs, err := action.TaskRuns(o.Client, pr)
+	if err != nil || o.archived {
+		return trs, err
+	}
+
+	dc, err := o.Factory.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	m, err := o.RESTMapper.RESTMapping(schema.GroupKind{Group: pipeline.GroupName, Kind: "TaskRun"})
+	if meta.IsNoMatchError(err) {
+		return trs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ul, err := dc.Resource(m.Resource).Namespace(pr.GetNamespace()).List(context.Background(), metav1.ListOptions{
+		LabelSelector: pipeline.PipelineRunLabelKey + "=" + pr.GetName(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	archived := map[types.UID]bool{}
+	for _, tr := range trs {
+		archived[tr.GetUID()] = true
+	}
+	// TaskRuns of a PipelineRun which was re-created with the same name are
+	// not owned by this one.
+	for _, tr := range ul.Items {
+		if !archived[tr.GetUID()] && ownedBy(&tr, pr.GetUID()) {
+			trs = append(trs, tr)
+		}
+	}
+
+	action.SortTaskRuns(pr, trs)
+	return trs, nil
+}
+
+// ownedBy reports whether an owner reference of the object has the UID.
+func ownedBy(u *unstructured.Unstructured, uid types.UID) bool {
+	for _, r := range u.GetOwnerReferences() {
+		if r.UID == uid {
+			return true
+		}
+	}
+	return false
+}
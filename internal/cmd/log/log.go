@@ -7,9 +7,10 @@ import (
 	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
 	"github.com/sayan-biswas/kubectl-tekton/internal/results/config"
 	"github.com/spf13/cobra"
-	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
@@ -19,6 +20,7 @@ import (
 	"k8s.io/kubectl/pkg/scheme"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
+	"regexp"
 )
 
 type logOptions struct {
@@ -29,26 +31,69 @@ type logOptions struct {
 	Namespace string
 	Resource  string
 	Name      string
+	Reference string
 	UID       string
 	Limit     int32
+	Task      string
+	Step      string
+	Tail      int64
+	SinceLine int64
+	Grep      string
+	OutputDir string
+	Runs      action.RunFilter
 
 	Client     client.Client
 	RESTMapper meta.RESTMapper
 
 	IOStreams *genericiooptions.IOStreams
 	Factory   util.Factory
+
+	out *filterWriter
+	// archived is set for runs resolved from a record, their TaskRuns are only
+	// looked up in tekton results.
+	archived bool
 }
 
 var (
 	logLong = templates.LongDesc(i18n.T(`
 		Display executions logs for resource from tekton results.
 
+		Logs of a PipelineRun are aggregated from all of its TaskRuns in pipeline order.
+
+		Runs which are not archived in tekton results yet are read from the cluster,
+		the container logs of their pods are printed until the log record exists.
+		The logs of running TaskRuns are followed until their steps complete.
+
 		You can use --uid to select a specific resource`))
 
 	logExample = templates.Examples(`
 		# Get logs from tekton results server
 		kubectl tekton log tr testrun 
 
+		# Get logs of all TaskRuns of a PipelineRun
+		kubectl tekton log pr testpr
+
+		# Get the last 100 lines of a step of a pipeline task
+		kubectl tekton log pr testpr --task build --step push --tail 100
+
+		# Get the lines of a TaskRun matching a regular expression
+		kubectl tekton log tr testrun --grep "(?i)error"
+
+		# Write the logs of a PipelineRun to a file per TaskRun and step
+		kubectl tekton log pr testpr --output-dir ./logs
+
+		# Get logs of the last run of a pipeline
+		kubectl tekton log pr --pipeline build
+
+		# Get logs of the last TaskRun of a task
+		kubectl tekton log tr --task build
+
+		# Get logs of the last run of a Pipelines-as-Code pull request
+		kubectl tekton log pr --repo my-repo --pull-request 42
+
+		# Get logs of the run of a record or a result by its name or UID
+		kubectl tekton log default/results/3d8c6c52-6a0b-4b7a-a4ab-2f4b8b3b3a5e
+
 		# Get logs for a particular run using UID
 		kubectl tekton config view --uid f27a6d83-21d3-4256-a8f0-0875b123895f `)
 )
@@ -69,7 +114,7 @@ func Command(s *genericiooptions.IOStreams, f util.Factory) *cobra.Command {
 		Short:   i18n.T("Display logs for a resource from tekton results"),
 		Long:    logLong,
 		Example: logExample,
-		Args:    cobra.ExactArgs(2),
+		Args:    cobra.RangeArgs(1, 2),
 		Run: func(cmd *cobra.Command, args []string) {
 			util.CheckErr(o.Complete(args))
 			util.CheckErr(o.Validate())
@@ -79,6 +124,17 @@ func Command(s *genericiooptions.IOStreams, f util.Factory) *cobra.Command {
 
 	o.PrintFlags.AddFlags(c)
 	c.Flags().StringVarP(&o.UID, "uid", "", "", "UID")
+	c.Flags().StringVarP(&o.Task, "task", "", "", "Only print logs of the pipeline task or TaskRun with this name, or select the last TaskRun of the task if no name is given")
+	c.Flags().StringVarP(&o.Step, "step", "", "", "Only print logs of the step with this name")
+	c.Flags().Int64VarP(&o.Tail, "tail", "", -1, "Lines of the end of the log to print, -1 prints all lines")
+	c.Flags().Int64VarP(&o.SinceLine, "since-line", "", 0, "Skip the lines of the log before this line number")
+	c.Flags().StringVarP(&o.Grep, "grep", "", "", "Only print lines matching the regular expression")
+	c.Flags().StringVarP(&o.OutputDir, "output-dir", "", "", "Write the logs to a file per TaskRun and step in this directory")
+	c.Flags().StringVarP(&o.Runs.Pipeline, "pipeline", "", "", "Select the last run of the pipeline")
+	c.Flags().StringVarP(&o.Runs.ParentRun, "parent-run", "", "", "Select the last TaskRun of the PipelineRun")
+	c.Flags().StringVarP(&o.Runs.Repository, "repo", "", "", "Select the last run of the Pipelines-as-Code repository")
+	c.Flags().StringVarP(&o.Runs.SHA, "sha", "", "", "Select the last run of the Pipelines-as-Code commit")
+	c.Flags().StringVarP(&o.Runs.PullRequest, "pull-request", "", "", "Select the last run of the Pipelines-as-Code pull request")
 
 	return c
 }
@@ -112,13 +168,33 @@ func (o *logOptions) Complete(args []string) (err error) {
 	}
 
 	switch len(args) {
+	case 1:
+		if action.IsReference(args[0]) {
+			o.Reference = args[0]
+		} else {
+			o.Resource = args[0]
+		}
 	case 2:
 		o.Resource = args[0]
 		o.Name = args[1]
 	default:
-		return errors.New("invalid arguments, there should be exactly 2 arguments")
+		return errors.New("invalid arguments, should of type RESOURCE NAME")
 	}
 
+	f := &filter{
+		Task:      o.Task,
+		Step:      o.Step,
+		SinceLine: o.SinceLine,
+		Tail:      o.Tail,
+	}
+	if o.Grep != "" {
+		f.Grep, err = regexp.Compile(o.Grep)
+		if err != nil {
+			return fmt.Errorf("invalid --grep expression: %w", err)
+		}
+	}
+	o.out = newFilterWriter(o.IOStreams.Out, f)
+
 	return nil
 }
 
@@ -127,11 +203,24 @@ func (o *logOptions) Validate() error {
 	if o.Namespace == "" {
 		return errors.New("namespace must be specified")
 	}
+	if o.Name == "" && o.Reference == "" && o.Task == "" && len(o.Runs.Labels()) == 0 {
+		return errors.New("name or one of --pipeline, --task, --parent-run, --repo, --sha or --pull-request must be specified")
+	}
+	if o.Tail < -1 {
+		return errors.New("tail should be -1 or a positive number")
+	}
+	if o.SinceLine < 0 {
+		return errors.New("since-line should be a positive number")
+	}
 	return nil
 }
 
 // Run performs the execution of 'config view' sub command
 func (o *logOptions) Run() error {
+	if o.Reference != "" {
+		return o.referenceLogs()
+	}
+
 	gvr, _, err := explain.SplitAndParseResourceRequest(o.Resource, o.RESTMapper)
 	if err != nil {
 		return err
@@ -144,6 +233,15 @@ func (o *logOptions) Run() error {
 
 	v, k := gvk.ToAPIVersionAndKind()
 
+	// Without a name --task selects the last TaskRun of the task, for
+	// PipelineRuns it only selects the logs of their TaskRuns.
+	if o.Name == "" && k == "TaskRun" {
+		o.Runs.Task = o.Task
+	}
+	if o.Name == "" && len(o.Runs.Labels()) == 0 {
+		return errors.New("name or one of --pipeline, --parent-run, --repo, --sha or --pull-request must be specified for PipelineRuns")
+	}
+
 	opts := &action.Options{
 		ListOptions: metav1.ListOptions{
 			TypeMeta: metav1.TypeMeta{
@@ -156,6 +254,7 @@ func (o *logOptions) Run() error {
 			Namespace: o.Namespace,
 			UID:       types.UID(o.UID),
 		},
+		Runs: o.Runs,
 	}
 
 	ul, err := action.List(o.Client, opts)
@@ -163,26 +262,87 @@ func (o *logOptions) Run() error {
 		return err
 	}
 
-	if len(ul.Items) == 0 {
-		return printers.WriteEscaped(o.IOStreams.Out, fmt.Sprintf("No %s found", gvk.Kind))
+	// Runs which are not archived yet are read from the cluster.
+	var run *unstructured.Unstructured
+	if len(ul.Items) > 0 {
+		run = &ul.Items[0]
+	} else {
+		run, err = o.liveRun(gvk.GroupKind())
+		if err != nil {
+			return err
+		}
 	}
-	a, exists := ul.Items[0].GetAnnotations()[annotation.Log]
-	if !exists || a == "" {
-		return printers.WriteEscaped(o.IOStreams.Out, "No logs found")
+	if run == nil {
+		return printers.WriteEscaped(o.IOStreams.Out, fmt.Sprintf("No %s found", gvk.Kind))
 	}
-	log, err := action.Log(o.Client, &action.Options{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: a,
-		},
-	})
+	return o.logs(run)
+}
+
+// referenceLogs prints the logs of the run of a record referenced by its
+// name, the name of its result or a UID.
+func (o *logOptions) referenceLogs() error {
+	run, err := action.Resolve(o.Client, o.Namespace, o.Reference)
 	if err != nil {
 		return err
 	}
+	switch run.GetKind() {
+	case "PipelineRun", "TaskRun":
+		o.archived = true
+		return o.logs(run)
+	}
+	return fmt.Errorf("%s is a %s, logs are only printed for PipelineRuns and TaskRuns", o.Reference, run.GetKind())
+}
 
-	_, err = o.IOStreams.Out.Write(log)
+// logs prints the logs of a run, of all TaskRuns for a PipelineRun.
+func (o *logOptions) logs(run *unstructured.Unstructured) error {
+	var err error
+	var trs []unstructured.Unstructured
+	if run.GetKind() == "PipelineRun" {
+		trs, err = o.taskRuns(run)
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.OutputDir != "" {
+		return o.writeDir(run, trs)
+	}
+
+	if len(trs) > 0 {
+		return o.taskRunLogs(trs)
+	}
+
+	_, err = o.runLog(run, o.out)
+	if errors.Is(err, errNoLogs) {
+		return printers.WriteEscaped(o.IOStreams.Out, "No logs found")
+	}
 	if err != nil {
 		return err
 	}
+	return o.out.Close()
+}
 
-	return nil
+// taskRunLogs prints the logs of the TaskRuns of a PipelineRun with the
+// pipeline task name and the step name as prefix.
+func (o *logOptions) taskRunLogs(trs []unstructured.Unstructured) error {
+	// TaskRuns are selected by the task name or the TaskRun name here, the
+	// lines don't have to be filtered by task again.
+	o.out.filter.Task = ""
+	for _, tr := range trs {
+		task := tr.GetLabels()[pipeline.PipelineTaskLabelKey]
+		if task == "" {
+			task = tr.GetName()
+		}
+		if o.Task != "" && o.Task != task && o.Task != tr.GetName() {
+			continue
+		}
+		w := newPrefixWriter(o.out, task)
+		if _, err := o.runLog(&tr, w); err != nil && !errors.Is(err, errNoLogs) {
+			return err
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	return o.out.Close()
 }
@@ -0,0 +1,170 @@
+package log
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// lineWriter splits the written bytes into lines and passes every complete
+// line, including the line break, to fn.
+type lineWriter struct {
+	fn   func(line []byte) error
+	line []byte
+}
+
+func (l *lineWriter) Write(b []byte) (int, error) {
+	n := len(b)
+	for len(b) > 0 {
+		i := bytes.IndexByte(b, '\n')
+		if i < 0 {
+			l.line = append(l.line, b...)
+			break
+		}
+		l.line = append(l.line, b[:i+1]...)
+		b = b[i+1:]
+		if err := l.Flush(); err != nil {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
+// Flush passes the buffered partial line, if any.
+func (l *lineWriter) Flush() error {
+	if len(l.line) == 0 {
+		return nil
+	}
+	line := l.line
+	l.line = l.line[:0]
+	return l.fn(line)
+}
+
+// newPrefixWriter rewrites the "[step] " prefix of TaskRun log lines to the
+// "[task : step] " prefix used for PipelineRun logs.
+func newPrefixWriter(w io.Writer, task string) *lineWriter {
+	return &lineWriter{fn: func(line []byte) error {
+		if len(bytes.TrimSpace(line)) == 0 {
+			_, err := w.Write(line)
+			return err
+		}
+
+		prefix := "[" + task + "] "
+		if _, step, rest, ok := splitPrefix(line); ok {
+			prefix = "[" + task + " : " + step + "] "
+			line = rest
+		}
+		if _, err := io.WriteString(w, prefix); err != nil {
+			return err
+		}
+		_, err := w.Write(line)
+		return err
+	}}
+}
+
+// newStepWriter prefixes every line with "[step] ", the format of the TaskRun
+// logs stored by the results watcher.
+func newStepWriter(w io.Writer, step string) *lineWriter {
+	return &lineWriter{fn: func(line []byte) error {
+		if _, err := io.WriteString(w, "["+step+"] "); err != nil {
+			return err
+		}
+		_, err := w.Write(line)
+		return err
+	}}
+}
+
+// filter selects the log lines to print.
+type filter struct {
+	Task      string
+	Step      string
+	Grep      *regexp.Regexp
+	SinceLine int64
+	Tail      int64
+}
+
+// filterWriter writes the lines which match the filter to w. Lines for --tail
+// are kept in a ring buffer until Close, so only the selected lines are held
+// in memory.
+type filterWriter struct {
+	*lineWriter
+	w      io.Writer
+	filter *filter
+	count  int64
+	tail   [][]byte
+	next   int
+}
+
+func newFilterWriter(w io.Writer, f *filter) *filterWriter {
+	fw := &filterWriter{w: w, filter: f}
+	fw.lineWriter = &lineWriter{fn: fw.writeLine}
+	return fw
+}
+
+func (f *filterWriter) writeLine(line []byte) error {
+	f.count++
+	if f.count < f.filter.SinceLine {
+		return nil
+	}
+
+	task, step, rest, ok := splitPrefix(line)
+	if f.filter.Task != "" && task != "" && task != f.filter.Task {
+		return nil
+	}
+	if f.filter.Step != "" && (!ok || step != f.filter.Step) {
+		return nil
+	}
+	if f.filter.Grep != nil && !f.filter.Grep.Match(rest) {
+		return nil
+	}
+
+	if f.filter.Tail < 0 {
+		_, err := f.w.Write(line)
+		return err
+	}
+	if f.filter.Tail == 0 {
+		return nil
+	}
+
+	line = append([]byte(nil), line...)
+	if int64(len(f.tail)) < f.filter.Tail {
+		f.tail = append(f.tail, line)
+		return nil
+	}
+	f.tail[f.next] = line
+	f.next = (f.next + 1) % len(f.tail)
+	return nil
+}
+
+// Close flushes the partial line and writes the lines kept for --tail.
+func (f *filterWriter) Close() error {
+	if err := f.Flush(); err != nil {
+		return err
+	}
+	for i := range f.tail {
+		if _, err := f.w.Write(f.tail[(f.next+i)%len(f.tail)]); err != nil {
+			return err
+		}
+	}
+	f.tail = nil
+	return nil
+}
+
+// splitPrefix splits a log line into the task name, the step name and the log.
+// TaskRun log lines are prefixed with "[step] ", PipelineRun log lines with
+// "[task : step] ".
+func splitPrefix(line []byte) (string, string, []byte, bool) {
+	if len(line) == 0 || line[0] != '[' {
+		return "", "", line, false
+	}
+	i := bytes.Index(line, []byte("] "))
+	if i < 0 {
+		return "", "", line, false
+	}
+	prefix, rest := string(line[1:i]), line[i+2:]
+	if task, step, found := strings.Cut(prefix, " : "); found {
+		return task, step, rest, true
+	}
+	return "", prefix, rest, true
+}
@@ -1,10 +1,13 @@
 package helper
 
 import (
+	"fmt"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
 )
 
 func ParseSelector(s string) map[string]string {
@@ -27,8 +30,13 @@ func ParseSelector(s string) map[string]string {
 	return m
 }
 
-func ParseLabels(s string) map[string]string {
-	return ParseSelector(s)
+// ParseLabels parses a label selector with the kubernetes selector grammar,
+// e.g. "app=build,env!=prod,tier in (web,api),!canary".
+func ParseLabels(s string) (labels.Selector, error) {
+	if strings.TrimSpace(s) == "" {
+		return labels.Everything(), nil
+	}
+	return labels.Parse(s)
 }
 
 func ParseAnnotations(s string) map[string]string {
@@ -88,3 +96,19 @@ func ParseArgs(args []string) map[string]string {
 	}
 	return m
 }
+
+// ParseTime parses a RFC3339 timestamp, or a duration which is subtracted
+// from the current time, e.g. 12h for 12 hours ago.
+func ParseTime(s string) (time.Time, error) {
+	if strings.TrimSpace(s) == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected a duration or a RFC3339 timestamp", s)
+	}
+	return t, nil
+}
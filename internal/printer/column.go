@@ -0,0 +1,37 @@
+package printer
+
+// Column is a column of a list which is printed for a single kind only.
+type Column struct {
+	Header string
+	// Wide columns are only printed with the wide output format.
+	Wide  bool
+	Value func(Item) string
+}
+
+var registry = map[string][]Column{}
+
+// RegisterColumns adds columns to the lists of a kind, they are printed after
+// the status column in the order of registration. Kinds plug in their columns
+// with an init function.
+func RegisterColumns(kind string, c ...Column) {
+	registry[kind] = append(registry[kind], c...)
+}
+
+// columns returns the registered columns of a kind.
+func columns(kind string, wide bool) []Column {
+	var cs []Column
+	for _, c := range registry[kind] {
+		if !c.Wide || wide {
+			cs = append(cs, c)
+		}
+	}
+	return cs
+}
+
+// orNone returns the placeholder of empty values.
+func orNone(s string) string {
+	if s == "" {
+		return "---"
+	}
+	return s
+}
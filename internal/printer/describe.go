@@ -0,0 +1,202 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/tektoncd/cli/pkg/formatted"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"knative.dev/pkg/apis"
+)
+
+// legacyStatus holds the results of v1beta1 records, which are renamed in v1.
+// TODO: remove after tekton results migration to V1 APIs
+type legacyStatus struct {
+	Status struct {
+		TaskResults     []v1.TaskRunResult     `json:"taskResults,omitempty"`
+		PipelineResults []v1.PipelineRunResult `json:"pipelineResults,omitempty"`
+	} `json:"status,omitempty"`
+}
+
+// TaskRun is a TaskRun of a described PipelineRun.
+type TaskRun struct {
+	*v1.TaskRun
+	Task string
+}
+
+// DescribePipelineRun prints the details of a PipelineRun and its TaskRuns.
+func DescribePipelineRun(w io.Writer, u *unstructured.Unstructured, trs []unstructured.Unstructured) error {
+	pr := new(v1.PipelineRun)
+	l := new(legacyStatus)
+	if err := decode(u, pr, l); err != nil {
+		return err
+	}
+	if len(pr.Status.Results) == 0 {
+		pr.Status.Results = l.Status.PipelineResults
+	}
+
+	var data = struct {
+		PipelineRun *v1.PipelineRun
+		ParamSpecs  []v1.ParamSpec
+		TaskRuns    []TaskRun
+		Time        clockwork.Clock
+	}{
+		PipelineRun: pr,
+		Time:        clockwork.NewRealClock(),
+	}
+	if pr.Status.PipelineSpec != nil {
+		data.ParamSpecs = pr.Status.PipelineSpec.Params
+	}
+	for i := range trs {
+		tr, err := taskRun(&trs[i])
+		if err != nil {
+			return err
+		}
+		data.TaskRuns = append(data.TaskRuns, TaskRun{
+			TaskRun: tr,
+			Task:    tr.Labels[pipeline.PipelineTaskLabelKey],
+		})
+	}
+
+	return describe(w, describePipelineRunTemplate, data)
+}
+
+// DescribeTaskRun prints the details of a TaskRun and its steps.
+func DescribeTaskRun(w io.Writer, u *unstructured.Unstructured) error {
+	tr, err := taskRun(u)
+	if err != nil {
+		return err
+	}
+
+	var data = struct {
+		TaskRun    *v1.TaskRun
+		ParamSpecs []v1.ParamSpec
+		Time       clockwork.Clock
+	}{
+		TaskRun: tr,
+		Time:    clockwork.NewRealClock(),
+	}
+	if tr.Status.TaskSpec != nil {
+		data.ParamSpecs = tr.Status.TaskSpec.Params
+	}
+
+	return describe(w, describeTaskRunTemplate, data)
+}
+
+func taskRun(u *unstructured.Unstructured) (*v1.TaskRun, error) {
+	tr := new(v1.TaskRun)
+	l := new(legacyStatus)
+	if err := decode(u, tr, l); err != nil {
+		return nil, err
+	}
+	if len(tr.Status.Results) == 0 {
+		tr.Status.Results = l.Status.TaskResults
+	}
+	return tr, nil
+}
+
+// decode decodes a record into the v1 type. The v1beta1 records are mostly
+// compatible, the renamed fields are decoded into the legacy status.
+func decode(u *unstructured.Unstructured, o interface{}, l *legacyStatus) error {
+	b, err := u.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, o); err != nil {
+		return fmt.Errorf("failed to decode %s %s: %w", u.GetKind(), u.GetName(), err)
+	}
+	return json.Unmarshal(b, l)
+}
+
+func describe(w io.Writer, text string, data interface{}) error {
+	funcMap := template.FuncMap{
+		"formatAge":       formatted.Age,
+		"formatDuration":  formatted.Duration,
+		"formatCondition": formatted.Condition,
+		"formatParam":     formatParam,
+		"formatResult":    formatted.Result,
+		"formatWorkspace": formatted.Workspace,
+		"formatLabels":    formatLabels,
+		"message":         message,
+		"stepStatus":      stepStatus,
+		"exitCode":        exitCode,
+		"stepDuration":    stepDuration,
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 5, 3, ' ', tabwriter.TabIndent)
+	t := template.Must(template.New("Describe").Funcs(funcMap).Parse(text))
+
+	if err := t.Execute(tw, data); err != nil {
+		return err
+	}
+
+	return tw.Flush()
+}
+
+// formatParam returns the value of a param, or the default of the param spec
+// for references to params.
+func formatParam(p v1.Param, specs []v1.ParamSpec) string {
+	return strings.TrimPrefix(formatted.Param([]v1.Param{p}, specs), p.Name+": ")
+}
+
+// formatLabels returns the labels or annotations as sorted key=value pairs.
+func formatLabels(m map[string]string) string {
+	m = formatted.RemoveLastAppliedConfig(m)
+	if len(m) == 0 {
+		return "---"
+	}
+	var s []string
+	for k, v := range m {
+		s = append(s, k+"="+v)
+	}
+	sort.Strings(s)
+	return strings.Join(s, ", ")
+}
+
+// message returns the message of a failed succeeded condition.
+func message(s duckStatus) string {
+	c := s.GetCondition(apis.ConditionSucceeded)
+	if c == nil || !c.IsFalse() {
+		return ""
+	}
+	return c.Message
+}
+
+type duckStatus interface {
+	GetCondition(apis.ConditionType) *apis.Condition
+}
+
+func stepStatus(s v1.StepState) string {
+	switch {
+	case s.Terminated != nil:
+		return formatted.ColorStatus(s.Terminated.Reason)
+	case s.Running != nil:
+		return formatted.ColorStatus("Running")
+	case s.Waiting != nil:
+		return formatted.ColorStatus("Pending") + "(" + s.Waiting.Reason + ")"
+	}
+	return "---"
+}
+
+func exitCode(s v1.StepState) string {
+	if s.Terminated == nil {
+		return "---"
+	}
+	return fmt.Sprint(s.Terminated.ExitCode)
+}
+
+func stepDuration(s corev1.ContainerState) string {
+	if s.Terminated == nil {
+		return "---"
+	}
+	return formatted.Duration(&s.Terminated.StartedAt, &s.Terminated.FinishedAt)
+}
@@ -0,0 +1,150 @@
+package printer
+
+const describePipelineRunTemplate = `{{- $pr := .PipelineRun -}}
+Name:	{{ $pr.Name }}
+Namespace:	{{ $pr.Namespace }}
+UID:	{{ $pr.UID }}
+{{- with $pr.Spec.PipelineRef }}
+Pipeline Ref:	{{ .Name }}{{ if .Resolver }} ({{ .Resolver }}){{ end }}
+{{- end }}
+Labels:	{{ formatLabels $pr.Labels }}
+Annotations:	{{ formatLabels $pr.Annotations }}
+
+Status
+
+STARTED	DURATION	STATUS
+{{ formatAge $pr.Status.StartTime $.Time }}	{{ formatDuration $pr.Status.StartTime $pr.Status.CompletionTime }}	{{ formatCondition $pr.Status.Conditions }}
+{{- with message $pr.Status }}
+
+Message
+
+{{ . }}
+{{- end }}
+
+Params
+
+{{- if $pr.Spec.Params }}
+ NAME	VALUE
+{{- range $p := $pr.Spec.Params }}
+ {{ $p.Name }}	{{ formatParam $p $.ParamSpecs }}
+{{- end }}
+{{- else }}
+ No params
+{{- end }}
+
+Results
+
+{{- if $pr.Status.Results }}
+ NAME	VALUE
+{{- range $r := $pr.Status.Results }}
+ {{ $r.Name }}	{{ formatResult $r.Value }}
+{{- end }}
+{{- else }}
+ No results
+{{- end }}
+
+Workspaces
+
+{{- if $pr.Spec.Workspaces }}
+ NAME	SUB PATH	WORKSPACE BINDING
+{{- range $w := $pr.Spec.Workspaces }}
+ {{ $w.Name }}	{{ if $w.SubPath }}{{ $w.SubPath }}{{ else }}---{{ end }}	{{ formatWorkspace $w }}
+{{- end }}
+{{- else }}
+ No workspaces
+{{- end }}
+
+TaskRuns
+
+{{- if .TaskRuns }}
+ NAME	TASK NAME	STARTED	DURATION	STATUS
+{{- range $tr := .TaskRuns }}
+ {{ $tr.Name }}	{{ $tr.Task }}	{{ formatAge $tr.Status.StartTime $.Time }}	{{ formatDuration $tr.Status.StartTime $tr.Status.CompletionTime }}	{{ formatCondition $tr.Status.Conditions }}
+{{- end }}
+{{- range $tr := .TaskRuns }}
+
+Steps of {{ $tr.Task }} ({{ $tr.Name }})
+
+{{- if $tr.Status.Steps }}
+ NAME	STATUS	EXIT CODE	DURATION
+{{- range $s := $tr.Status.Steps }}
+ {{ $s.Name }}	{{ stepStatus $s }}	{{ exitCode $s }}	{{ stepDuration $s.ContainerState }}
+{{- end }}
+{{- else }}
+ No steps
+{{- end }}
+{{- with message $tr.Status }}
+ Message: {{ . }}
+{{- end }}
+{{- end }}
+{{- else }}
+ No taskruns
+{{- end }}
+`
+
+const describeTaskRunTemplate = `{{- $tr := .TaskRun -}}
+Name:	{{ $tr.Name }}
+Namespace:	{{ $tr.Namespace }}
+UID:	{{ $tr.UID }}
+{{- with $tr.Spec.TaskRef }}
+Task Ref:	{{ .Name }}{{ if .Resolver }} ({{ .Resolver }}){{ end }}
+{{- end }}
+Pod:	{{ if $tr.Status.PodName }}{{ $tr.Status.PodName }}{{ else }}---{{ end }}
+Labels:	{{ formatLabels $tr.Labels }}
+Annotations:	{{ formatLabels $tr.Annotations }}
+
+Status
+
+STARTED	DURATION	STATUS
+{{ formatAge $tr.Status.StartTime $.Time }}	{{ formatDuration $tr.Status.StartTime $tr.Status.CompletionTime }}	{{ formatCondition $tr.Status.Conditions }}
+{{- with message $tr.Status }}
+
+Message
+
+{{ . }}
+{{- end }}
+
+Params
+
+{{- if $tr.Spec.Params }}
+ NAME	VALUE
+{{- range $p := $tr.Spec.Params }}
+ {{ $p.Name }}	{{ formatParam $p $.ParamSpecs }}
+{{- end }}
+{{- else }}
+ No params
+{{- end }}
+
+Results
+
+{{- if $tr.Status.Results }}
+ NAME	VALUE
+{{- range $r := $tr.Status.Results }}
+ {{ $r.Name }}	{{ formatResult $r.Value }}
+{{- end }}
+{{- else }}
+ No results
+{{- end }}
+
+Workspaces
+
+{{- if $tr.Spec.Workspaces }}
+ NAME	SUB PATH	WORKSPACE BINDING
+{{- range $w := $tr.Spec.Workspaces }}
+ {{ $w.Name }}	{{ if $w.SubPath }}{{ $w.SubPath }}{{ else }}---{{ end }}	{{ formatWorkspace $w }}
+{{- end }}
+{{- else }}
+ No workspaces
+{{- end }}
+
+Steps
+
+{{- if $tr.Status.Steps }}
+ NAME	STATUS	EXIT CODE	DURATION
+{{- range $s := $tr.Status.Steps }}
+ {{ $s.Name }}	{{ stepStatus $s }}	{{ exitCode $s }}	{{ stepDuration $s.ContainerState }}
+{{- end }}
+{{- else }}
+ No steps
+{{- end }}
+`
@@ -0,0 +1,54 @@
+package printer
+
+import (
+	"regexp"
+	"strconv"
+
+	"knative.dev/pkg/apis"
+)
+
+// The succeeded condition of a PipelineRun counts its child tasks in the
+// message, e.g. "Tasks Completed: 3 (Failed: 1, Cancelled 0), Skipped: 2".
+var (
+	completedTasks = regexp.MustCompile(`Tasks Completed: (\d+) \(Failed: (\d+), Cancelled:? (\d+)\)`)
+	skippedTasks   = regexp.MustCompile(`Skipped: (\d+)`)
+)
+
+func init() {
+	RegisterColumns("PipelineRun",
+		Column{Header: "PIPELINE", Value: Item.Ref},
+		Column{Header: "SUCCEEDED", Value: func(i Item) string {
+			m := completedTasks.FindStringSubmatch(i.message())
+			if m == nil {
+				return "---"
+			}
+			return strconv.Itoa(atoi(m[1]) - atoi(m[2]) - atoi(m[3]))
+		}},
+		Column{Header: "FAILED", Value: func(i Item) string {
+			m := completedTasks.FindStringSubmatch(i.message())
+			if m == nil {
+				return "---"
+			}
+			return m[2]
+		}},
+		Column{Header: "SKIPPED", Value: func(i Item) string {
+			if m := skippedTasks.FindStringSubmatch(i.message()); m != nil {
+				return m[1]
+			}
+			return strconv.Itoa(len(i.Status.SkippedTasks))
+		}},
+	)
+}
+
+// message returns the message of the succeeded condition.
+func (i Item) message() string {
+	if c := i.Status.GetCondition(apis.ConditionSucceeded); c != nil {
+		return c.Message
+	}
+	return ""
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
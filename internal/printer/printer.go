@@ -4,6 +4,7 @@ import (
 	"github.com/jonboulle/clockwork"
 	"github.com/tektoncd/cli/pkg/formatted"
 	"io"
+	"strings"
 	"text/tabwriter"
 	"text/template"
 
@@ -19,18 +20,37 @@ func PrintObject(w io.Writer, o runtime.Object, f *cliopts.PrintFlags) error {
 	return printer.PrintObj(o, w)
 }
 
-func PrintList(w io.Writer, l *List) error {
+// ListOptions controls the columns of a printed list.
+type ListOptions struct {
+	// AllNamespaces adds the namespace column.
+	AllNamespaces bool
+	// NoHeaders omits the column headers.
+	NoHeaders bool
+	// ShowSource adds a column with the source of each item.
+	ShowSource bool
+	// Wide adds the namespace, reason and result columns and the wide columns
+	// of the kind.
+	Wide bool
+}
+
+func PrintList(w io.Writer, l *List, o *ListOptions) error {
 
 	var data = struct {
 		List          *List
+		Columns       []Column
 		Time          clockwork.Clock
 		AllNamespaces bool
 		NoHeaders     bool
+		ShowSource    bool
+		Wide          bool
 	}{
 		List:          l,
+		Columns:       columns(l.Kind, o.Wide),
 		Time:          clockwork.NewRealClock(),
-		AllNamespaces: false,
-		NoHeaders:     false,
+		AllNamespaces: o.AllNamespaces,
+		NoHeaders:     o.NoHeaders,
+		ShowSource:    o.ShowSource,
+		Wide:          o.Wide,
 	}
 
 	funcMap := template.FuncMap{
@@ -49,3 +69,35 @@ func PrintList(w io.Writer, l *List) error {
 
 	return tw.Flush()
 }
+
+// PrintMatches prints runs of different kinds with the results and params
+// they were found by.
+func PrintMatches(w io.Writer, l *List, o *ListOptions) error {
+	var data = struct {
+		List          *List
+		Time          clockwork.Clock
+		AllNamespaces bool
+		NoHeaders     bool
+	}{
+		List:          l,
+		Time:          clockwork.NewRealClock(),
+		AllNamespaces: o.AllNamespaces,
+		NoHeaders:     o.NoHeaders,
+	}
+
+	funcMap := template.FuncMap{
+		"formatAge":       formatted.Age,
+		"formatDuration":  formatted.Duration,
+		"formatCondition": formatted.Condition,
+		"join":            strings.Join,
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 5, 3, ' ', tabwriter.TabIndent)
+	t := template.Must(template.New("List Matches").Funcs(funcMap).Parse(matchTemplate))
+
+	if err := t.Execute(tw, data); err != nil {
+		return err
+	}
+
+	return tw.Flush()
+}
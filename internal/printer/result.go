@@ -0,0 +1,187 @@
+package printer
+
+import (
+	"encoding/json"
+	"github.com/jonboulle/clockwork"
+	"github.com/tektoncd/cli/pkg/formatted"
+	results "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+// Result is a result with the summary of its main record.
+type Result struct {
+	Namespace string
+	Name      string
+	UID       string
+	Type      string
+	Status    string
+	Record    string
+	StartTime *metav1.Time
+	EndTime   *metav1.Time
+}
+
+// NewResult returns the printed fields of a result.
+func NewResult(r *results.Result) Result {
+	namespace, name, _ := strings.Cut(r.GetName(), "/results/")
+	s := r.GetSummary()
+	return Result{
+		Namespace: namespace,
+		Name:      name,
+		UID:       r.GetUid(),
+		Type:      kind(s.GetType()),
+		Status:    summaryStatus(s),
+		Record:    orNone(s.GetRecord()),
+		StartTime: metaTime(s.GetStartTime()),
+		EndTime:   metaTime(s.GetEndTime()),
+	}
+}
+
+// Record is a record of a result.
+type Record struct {
+	Name       string
+	UID        string
+	Type       string
+	Object     string
+	CreateTime *metav1.Time
+	UpdateTime *metav1.Time
+}
+
+// NewRecord returns the printed fields of a record, the object is the name of
+// the archived run, or of the run of a log.
+func NewRecord(r *results.Record) Record {
+	var data struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Spec struct {
+			Resource struct {
+				Name string `json:"name"`
+			} `json:"resource"`
+		} `json:"spec"`
+	}
+	_ = json.Unmarshal(r.GetData().GetValue(), &data)
+
+	object := data.Spec.Resource.Name
+	if object == "" {
+		object = data.Metadata.Name
+	}
+
+	_, name, _ := strings.Cut(r.GetName(), "/records/")
+	return Record{
+		Name:       name,
+		UID:        r.GetUid(),
+		Type:       kind(r.GetData().GetType()),
+		Object:     orNone(object),
+		CreateTime: metaTime(r.GetCreateTime()),
+		UpdateTime: metaTime(r.GetUpdateTime()),
+	}
+}
+
+// Tree is a result with its records.
+type Tree struct {
+	Result  Result
+	Records []Record
+}
+
+// kind returns the kind of a type, e.g. PipelineRun of
+// tekton.dev/v1beta1.PipelineRun.
+func kind(t string) string {
+	return orNone(t[strings.LastIndex(t, ".")+1:])
+}
+
+// summaryStatus returns the status of a summary in the words of the
+// succeeded condition, a summary without status and end is still running.
+func summaryStatus(s *results.RecordSummary) string {
+	switch s.GetStatus() {
+	case results.RecordSummary_SUCCESS:
+		return "Succeeded"
+	case results.RecordSummary_FAILURE:
+		return "Failed"
+	case results.RecordSummary_TIMEOUT:
+		return "Timeout"
+	case results.RecordSummary_CANCELLED:
+		return "Cancelled"
+	}
+	if s != nil && s.GetEndTime() == nil {
+		return "Running"
+	}
+	return "Unknown"
+}
+
+func metaTime(t *timestamppb.Timestamp) *metav1.Time {
+	if t == nil {
+		return nil
+	}
+	return &metav1.Time{Time: t.AsTime()}
+}
+
+// PrintResults prints the results with the summary of their main record.
+func PrintResults(w io.Writer, rs []Result, o *ListOptions) error {
+	var data = struct {
+		Results       []Result
+		Time          clockwork.Clock
+		AllNamespaces bool
+		NoHeaders     bool
+		Wide          bool
+	}{
+		Results:       rs,
+		Time:          clockwork.NewRealClock(),
+		AllNamespaces: o.AllNamespaces,
+		NoHeaders:     o.NoHeaders,
+		Wide:          o.Wide,
+	}
+	return printTemplate(w, "List Results", resultTemplate, data)
+}
+
+// PrintRecords prints the records of a result.
+func PrintRecords(w io.Writer, rs []Record, o *ListOptions) error {
+	var data = struct {
+		Records   []Record
+		Time      clockwork.Clock
+		NoHeaders bool
+	}{
+		Records:   rs,
+		Time:      clockwork.NewRealClock(),
+		NoHeaders: o.NoHeaders,
+	}
+	return printTemplate(w, "List Records", recordTemplate, data)
+}
+
+// PrintTree prints the results with their records as a tree.
+func PrintTree(w io.Writer, ts []Tree) error {
+	var data = struct {
+		Trees []Tree
+		Time  clockwork.Clock
+	}{
+		Trees: ts,
+		Time:  clockwork.NewRealClock(),
+	}
+	return printTemplate(w, "Tree", treeTemplate, data)
+}
+
+func printTemplate(w io.Writer, name, text string, data interface{}) error {
+	funcMap := template.FuncMap{
+		"formatAge":      formatted.Age,
+		"formatDuration": formatted.Duration,
+		"branch": func(i, n int) string {
+			if i == n-1 {
+				return "└── "
+			}
+			return "├── "
+		},
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 5, 3, ' ', tabwriter.TabIndent)
+	t := template.Must(template.New(name).Funcs(funcMap).Parse(text))
+
+	if err := t.Execute(tw, data); err != nil {
+		return err
+	}
+
+	return tw.Flush()
+}
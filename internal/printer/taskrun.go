@@ -0,0 +1,25 @@
+package printer
+
+import (
+	"strconv"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+)
+
+func init() {
+	RegisterColumns("TaskRun",
+		Column{Header: "PIPELINERUN", Value: func(i Item) string {
+			return orNone(i.Labels[pipeline.PipelineRunLabelKey])
+		}},
+		Column{Header: "PIPELINE TASK", Value: func(i Item) string {
+			return orNone(i.Labels[pipeline.PipelineTaskLabelKey])
+		}},
+		Column{Header: "POD", Value: func(i Item) string {
+			return orNone(i.Status.PodName)
+		}},
+		Column{Header: "RETRIES", Value: func(i Item) string {
+			return strconv.Itoa(len(i.Status.RetriesStatus))
+		}},
+		Column{Header: "TASK", Wide: true, Value: Item.Ref},
+	)
+}
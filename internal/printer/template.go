@@ -4,15 +4,50 @@ const listTemplate = `{{- $length := len .List.Items -}}{{- if eq $length 0 -}}
 No {{ .List.Kind }} found
 {{ else -}}
 {{- if not $.NoHeaders -}}
-{{- if $.AllNamespaces -}}
-NAMESPACE	NAME	STARTED	DURATION	STATUS	UID
+{{ if or $.AllNamespaces $.Wide }}NAMESPACE	{{ end }}NAME	STARTED	DURATION	STATUS{{ range $.Columns }}	{{ .Header }}{{ end }}{{ if $.Wide }}	REASON	RESULT{{ end }}	UID{{ if $.ShowSource }}	SOURCE{{ end }}
+{{ end -}}
+{{- range $_, $item := .List.Items }}{{- if $item -}}
+{{ if or $.AllNamespaces $.Wide }}{{ $item.Namespace }}	{{ end }}{{ $item.Name }}	{{ formatAge $item.Status.StartTime $.Time }}	{{ formatDuration $item.Status.StartTime $item.Status.CompletionTime }}	{{ formatCondition $item.Status.Conditions }}{{ range $.Columns }}	{{ call .Value $item }}{{ end }}{{ if $.Wide }}	{{ $item.Reason }}	{{ $item.ResultName }}{{ end }}	{{ $item.UID }}{{ if $.ShowSource }}	{{ $item.Source }}{{ end }}
+{{ end -}}{{- end -}}
+{{- end -}}`
+
+const matchTemplate = `{{- $length := len .List.Items -}}{{- if eq $length 0 -}}
+No runs found
 {{ else -}}
-NAME	STARTED	DURATION	STATUS	UID
+{{- if not $.NoHeaders -}}
+{{ if $.AllNamespaces }}NAMESPACE	{{ end }}KIND	NAME	PIPELINERUN	STARTED	DURATION	STATUS	MATCH
 {{ end -}}
-{{- end -}}
-{{- range $_, $item := .List.Items }}{{- if $item }}{{- if $.AllNamespaces -}}
-{{ $item.Namespace }}	{{ $item.Name }}	{{ formatAge $item.Status.StartTime $.Time }}	{{ formatDuration $item.Status.StartTime $item.Status.CompletionTime }}	{{ formatCondition $item.Status.Conditions }}	{{ $item.UID }}
+{{- range $_, $item := .List.Items }}{{- if $item -}}
+{{ if $.AllNamespaces }}{{ $item.Namespace }}	{{ end }}{{ $item.Kind }}	{{ $item.Name }}	{{ $item.PipelineRun }}	{{ formatAge $item.Status.StartTime $.Time }}	{{ formatDuration $item.Status.StartTime $item.Status.CompletionTime }}	{{ formatCondition $item.Status.Conditions }}	{{ join $item.Match ", " }}
+{{ end -}}{{- end -}}
+{{- end -}}`
+
+const resultTemplate = `{{- $length := len .Results -}}{{- if eq $length 0 -}}
+No results found
 {{ else -}}
-{{ $item.Name }}	{{ formatAge $item.Status.StartTime $.Time }}	{{ formatDuration $item.Status.StartTime $item.Status.CompletionTime }}	{{ formatCondition $item.Status.Conditions }}	{{ $item.UID }}
-{{ end -}}{{- end -}}{{- end -}}
+{{- if not $.NoHeaders -}}
+{{ if $.AllNamespaces }}NAMESPACE	{{ end }}NAME	TYPE	STARTED	DURATION	STATUS{{ if $.Wide }}	RECORD{{ end }}	UID
+{{ end -}}
+{{- range $_, $r := .Results }}
+{{- if $.AllNamespaces }}{{ $r.Namespace }}	{{ end }}{{ $r.Name }}	{{ $r.Type }}	{{ formatAge $r.StartTime $.Time }}	{{ formatDuration $r.StartTime $r.EndTime }}	{{ $r.Status }}{{ if $.Wide }}	{{ $r.Record }}{{ end }}	{{ $r.UID }}
+{{ end -}}
+{{- end -}}`
+
+const recordTemplate = `{{- $length := len .Records -}}{{- if eq $length 0 -}}
+No records found
+{{ else -}}
+{{- if not $.NoHeaders -}}
+NAME	TYPE	OBJECT	CREATED	UPDATED	UID
+{{ end -}}
+{{- range $_, $r := .Records }}
+{{- $r.Name }}	{{ $r.Type }}	{{ $r.Object }}	{{ formatAge $r.CreateTime $.Time }}	{{ formatAge $r.UpdateTime $.Time }}	{{ $r.UID }}
+{{ end -}}
+{{- end -}}`
+
+const treeTemplate = `{{- range $_, $t := .Trees }}
+{{- with $r := $t.Result }}{{ $r.Namespace }}/results/{{ $r.Name }}	{{ $r.Type }}	{{ $r.Status }}	{{ formatAge $r.StartTime $.Time }}
+{{ end -}}
+{{- $n := len $t.Records }}{{ range $i, $r := $t.Records }}
+{{- branch $i $n }}{{ $r.Name }}	{{ $r.Type }}	{{ $r.Object }}	{{ formatAge $r.CreateTime $.Time }}
+{{ end -}}
 {{- end -}}`
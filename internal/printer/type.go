@@ -1,21 +1,87 @@
 package printer
 
 import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
 	v1 "knative.dev/pkg/apis/duck/v1"
 )
 
 type List struct {
 	runtime.TypeMeta `json:",inline"`
 	NextPageToken    string `json:"nextPageToken,omitempty" yaml:"nextPageToken,omitempty"`
-	Items            []struct {
-		metav1.TypeMeta   `json:",inline"`
-		metav1.ObjectMeta `json:"metadata,omitempty"`
-		Status            struct {
-			v1.Status      `json:",inline"`
-			StartTime      *metav1.Time `json:"startTime,omitempty"`
-			CompletionTime *metav1.Time `json:"completionTime,omitempty"`
-		} `json:"status,omitempty"`
-	} `json:"items"`
+	Items            []Item `json:"items"`
+}
+
+type Item struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		PipelineRef *Ref `json:"pipelineRef,omitempty"`
+		TaskRef     *Ref `json:"taskRef,omitempty"`
+	} `json:"spec,omitempty"`
+	Status struct {
+		v1.Status      `json:",inline"`
+		StartTime      *metav1.Time `json:"startTime,omitempty"`
+		CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+		PodName        string       `json:"podName,omitempty"`
+		RetriesStatus  []struct{}   `json:"retriesStatus,omitempty"`
+		SkippedTasks   []struct {
+			Name string `json:"name"`
+		} `json:"skippedTasks,omitempty"`
+	} `json:"status,omitempty"`
+	// Source is where the item was found, the cluster, tekton results or both.
+	Source string `json:"-"`
+	// Match are the results and params of the item which were searched for.
+	Match []string `json:"-"`
+}
+
+type Ref struct {
+	Name     string `json:"name,omitempty"`
+	Resolver string `json:"resolver,omitempty"`
+}
+
+// Reason returns the reason of the succeeded condition.
+func (i Item) Reason() string {
+	if c := i.Status.GetCondition(apis.ConditionSucceeded); c != nil && c.Reason != "" {
+		return c.Reason
+	}
+	return "---"
+}
+
+// Ref returns the name of the referenced pipeline or task, or the resolver
+// of a remote reference.
+func (i Item) Ref() string {
+	for _, r := range []*Ref{i.Spec.PipelineRef, i.Spec.TaskRef} {
+		switch {
+		case r == nil:
+		case r.Name != "":
+			return r.Name
+		case r.Resolver != "":
+			return r.Resolver
+		}
+	}
+	return "---"
+}
+
+// ResultName returns the name of the result the item is archived in.
+func (i Item) ResultName() string {
+	if r := i.Annotations[annotation.Result]; r != "" {
+		return r
+	}
+	return "---"
+}
+
+// PipelineRun returns the name of the PipelineRun the item belongs to, the
+// item itself for PipelineRuns.
+func (i Item) PipelineRun() string {
+	if i.Kind == "PipelineRun" {
+		return i.Name
+	}
+	if pr := i.Labels[pipeline.PipelineRunLabelKey]; pr != "" {
+		return pr
+	}
+	return "---"
 }
@@ -3,9 +3,9 @@ package action
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
 	results "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"io"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -14,11 +14,15 @@ func List(c client.Client, o *Options) (*unstructured.UnstructuredList, error) {
 	if err != nil {
 		return nil, err
 	}
+	filter, err := o.filter()
+	if err != nil {
+		return nil, err
+	}
 
 	rl, err := c.ListRecords(context.Background(), &results.ListRecordsRequest{
-		Parent:    fmt.Sprintf("%s/results/-", o.Namespace),
-		Filter:    o.filter(),
-		OrderBy:   "update_time desc",
+		Parent:    o.parent(),
+		Filter:    filter,
+		OrderBy:   o.OrderBy,
 		PageSize:  int32(o.ListOptions.Limit),
 		PageToken: o.ListOptions.Continue,
 	})
@@ -41,22 +45,27 @@ func List(c client.Client, o *Options) (*unstructured.UnstructuredList, error) {
 		if err != nil {
 			return nil, err
 		}
-		ul.Items = append(ul.Items, *u)
+		if o.Match(u) {
+			ul.Items = append(ul.Items, *u)
+		}
 	}
 
 	return ul, nil
 }
 
-func Log(c client.Client, o *Options) ([]byte, error) {
+// Log writes the log referenced by the options name to w as it is received,
+// until the stream is complete.
+func Log(c client.Client, o *Options, w io.Writer) error {
 	lc, err := c.GetLog(context.Background(), &results.GetLogRequest{
 		Name: o.Name,
 	})
 	if err != nil {
-		return nil, err
+		return err
 	}
-	l, err := lc.Recv()
+	r, err := newLogReader(lc)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return l.GetData(), nil
+	_, err = io.Copy(w, r)
+	return err
 }
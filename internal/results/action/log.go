@@ -0,0 +1,42 @@
+package action
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	results "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"io"
+)
+
+// logReader reads the log chunks of a stream as one continuous io.Reader.
+type logReader struct {
+	stream results.Logs_GetLogClient
+	buffer []byte
+}
+
+func (r *logReader) Read(p []byte) (int, error) {
+	for len(r.buffer) == 0 {
+		l, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buffer = l.GetData()
+	}
+	n := copy(p, r.buffer)
+	r.buffer = r.buffer[n:]
+	return n, nil
+}
+
+// newLogReader returns a reader for the log stream, which is decompressed if
+// the stored log is gzip encoded.
+func newLogReader(s results.Logs_GetLogClient) (io.Reader, error) {
+	br := bufio.NewReader(&logReader{stream: s})
+	magic, err := br.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		return gzip.NewReader(br)
+	}
+	return br, nil
+}
@@ -0,0 +1,99 @@
+package action
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/query"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sort"
+	"strings"
+)
+
+// resultFields are the fields of the results of runs, taskResults and
+// pipelineResults of v1beta1 and results of v1.
+var resultFields = [][]string{
+	{"status", "taskResults"},
+	{"status", "pipelineResults"},
+	{"status", "results"},
+}
+
+var paramFields = [][]string{
+	{"spec", "params"},
+}
+
+// matchFilter narrows down the records to the ones of which the JSON of the
+// field contains the names and values, tekton results can't filter by the
+// elements of lists. The runs are matched exactly by Match.
+func matchFilter(field query.Value, values map[string]string) []query.Expr {
+	var filters []query.Expr
+	for _, name := range sortedKeys(values) {
+		filters = append(filters, query.Contains(field, `"`+jsonText(name)+`"`))
+		if v := values[name]; v != "" {
+			filters = append(filters, query.Contains(field, jsonText(v)))
+		}
+	}
+	return filters
+}
+
+// jsonText returns s escaped like in a JSON string, without the quotes.
+func jsonText(s string) string {
+	var b bytes.Buffer
+	e := json.NewEncoder(&b)
+	e.SetEscapeHTML(false)
+	_ = e.Encode(s)
+	t := strings.TrimSpace(b.String())
+	return t[1 : len(t)-1]
+}
+
+// Matches returns the results and params of a run matching the names and
+// values, formatted as name=value.
+func Matches(u *unstructured.Unstructured, results, params map[string]string) []string {
+	var m []string
+	add := func(name, value string) {
+		m = append(m, name+"="+value)
+	}
+	each(u, resultFields, results, add)
+	each(u, paramFields, params, add)
+	return m
+}
+
+// matchAll reports whether a run has an element of the fields matching each
+// of the names and values.
+func matchAll(u *unstructured.Unstructured, fields [][]string, values map[string]string) bool {
+	found := make(map[string]bool)
+	each(u, fields, values, func(name, _ string) {
+		found[name] = true
+	})
+	return len(found) == len(values)
+}
+
+// each calls f with the name and value of the elements of the fields which
+// match the names and values, or only the names if the value is empty.
+func each(u *unstructured.Unstructured, fields [][]string, values map[string]string, f func(name, value string)) {
+	for _, field := range fields {
+		elements, _, _ := unstructured.NestedSlice(u.Object, field...)
+		for _, e := range elements {
+			e, _ := e.(map[string]interface{})
+			name, _ := e["name"].(string)
+			want, ok := values[name]
+			if !ok {
+				continue
+			}
+			value := fmt.Sprint(e["value"])
+			if want != "" && value != want {
+				continue
+			}
+			f(name, value)
+		}
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
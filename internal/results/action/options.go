@@ -2,85 +2,211 @@ package action
 
 import (
 	"fmt"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/query"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"reflect"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"strings"
+	"time"
 )
 
 type Options struct {
-	metav1.ListOptions
+	metav1.ListOptions `json:"-"`
 	metav1.ObjectMeta
 	Filter string
+	// Result restricts the records to a single result, in the form <namespace>/results/<uid>.
+	Result string
+	// CreatedAfter restricts the records to runs created after the time.
+	CreatedAfter time.Time
+	// Since and Until restrict the records to runs started after and
+	// completed before the time.
+	Since time.Time
+	Until time.Time
+	// Status restricts the records to runs with the status, one of Statuses.
+	Status string
+	// Selector restricts the records to the ones with matching labels.
+	Selector labels.Selector
+	// Runs restricts the records to runs selected by their labels.
+	Runs RunFilter
+	// Results and Params restrict the records to runs with a result or a
+	// param of the name, and of the value unless it is empty.
+	Results map[string]string
+	Params  map[string]string
+	// OrderBy is the order of the records, "update_time desc" by default.
+	OrderBy string
 }
 
-func (o *Options) validate() error {
+func (o *Options) validate() (err error) {
 	switch {
 	case o.Namespace == "":
 		o.Namespace = "-"
 	}
-	return nil
+	if o.OrderBy == "" {
+		o.OrderBy = "update_time desc"
+	}
+	if o.Status != "" {
+		o.Status, err = status(o.Status)
+	}
+	return err
 }
 
-func (o *Options) filter() string {
-	const (
-		contains = "data.metadata.%s.contains(\"%s\")"
-		equal    = "data.metadata.%s[\"%s\"]==\"%s\""
-		dataType = "data_type==\"%s.%s\""
-	)
+func (o *Options) parent() string {
+	if o.Result != "" {
+		return o.Result
+	}
+	return fmt.Sprintf("%s/results/-", o.Namespace)
+}
 
-	var filters []string
+func (o *Options) filter() (string, error) {
+	metadata := query.Field("data", "metadata")
+	field := metadata.Field
 
-	if strings.TrimSpace(o.Filter) != "" {
-		filters = append(filters, o.Filter)
-	}
+	filters := []query.Expr{query.Raw(o.Filter)}
 
 	if o.Kind != "" && o.APIVersion != "" {
-		filters = append(filters, fmt.Sprintf(dataType, o.APIVersion, o.Kind))
+		filters = append(filters, query.Eq(query.Field("data_type"), query.String(o.APIVersion+"."+o.Kind)))
 	}
 
-	// TODO: add support for other types
-	v := reflect.ValueOf(o.ObjectMeta)
-	for i := 0; i < v.NumField(); i++ {
-		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
-		if len(name) == 0 {
-			name = v.Type().Field(i).Name
+	if o.Name != "" {
+		filters = append(filters, query.Eq(field("name"), query.String(o.Name)))
+	}
+	if o.GenerateName != "" {
+		filters = append(filters, query.StartsWith(field("name"), o.GenerateName))
+	}
+	if o.UID != "" {
+		filters = append(filters, query.Eq(field("uid"), query.String(string(o.UID))))
+	}
+	filters = append(filters, mapFilter(field("labels"), o.Labels)...)
+	filters = append(filters, mapFilter(field("labels"), o.Runs.Labels())...)
+	filters = append(filters, mapFilter(field("annotations"), o.Annotations)...)
+	for _, f := range o.Finalizers {
+		filters = append(filters, query.Contains(field("finalizers"), f))
+	}
+	for _, r := range o.OwnerReferences {
+		filters = append(filters, ownerFilter(field("ownerReferences"), r)...)
+	}
+
+	filters = append(filters, matchFilter(query.Field("data", "status"), o.Results)...)
+	filters = append(filters, matchFilter(query.Field("data", "spec"), o.Params)...)
+
+	if o.Status != "" {
+		filters = append(filters, statusFilter(o.Status))
+	}
+
+	if o.Selector != nil {
+		filters = append(filters, selectorFilter(o.Selector)...)
+	}
+
+	if !o.CreatedAfter.IsZero() {
+		filters = append(filters, query.After(field("creationTimestamp"), o.CreatedAfter))
+	}
+	if !o.Since.IsZero() {
+		filters = append(filters, query.After(query.Field("data", "status", "startTime"), o.Since))
+	}
+	if !o.Until.IsZero() {
+		filters = append(filters, query.Before(query.Field("data", "status", "completionTime"), o.Until))
+	}
+
+	f := query.And(filters...)
+	return f.String(), f.Err()
+}
+
+// mapFilter matches the values of a map. Keys with an empty value only have
+// to exist, which tekton results can't filter by exactly, the records are
+// narrowed down to the ones containing the key and matched by Match.
+func mapFilter(m query.Value, values map[string]string) []query.Expr {
+	var filters []query.Expr
+	for _, k := range sortedKeys(values) {
+		if v := values[k]; v != "" {
+			filters = append(filters, query.Eq(m.Index(k), query.String(v)))
+		} else {
+			filters = append(filters, query.Contains(m, k))
 		}
-		value := v.Field(i).Interface()
-		k := v.Type().Field(i).Type.Kind()
-		switch k {
-		case reflect.String:
-			if fmt.Sprintf("%v", value) != "" {
-				filters = append(filters, fmt.Sprintf(contains, name, value))
-			}
-		case reflect.Map:
-			if m := value.(map[string]string); len(m) > 0 {
-				for k, v := range m {
-					if v == "" {
-						filters = append(filters, fmt.Sprintf(contains, name, k))
-					} else {
-						filters = append(filters, fmt.Sprintf(equal, name, k, v))
-					}
-				}
-			}
-		case reflect.Slice:
-			s := reflect.ValueOf(value)
-			k := s.Type().Elem().Kind()
-			for i := 0; i < s.Len(); i++ {
-				v := s.Index(i)
-				switch k {
-				case reflect.Struct:
-					for i := 0; i < v.NumField(); i++ {
-						if v := v.Field(i); !v.IsZero() {
-							value := v.Interface()
-							filters = append(filters, fmt.Sprintf(contains, name, value))
-						}
-					}
-				case reflect.String, reflect.Int:
-					value := v.Interface()
-					filters = append(filters, fmt.Sprintf(contains, name, value))
-				}
-			}
+	}
+	return filters
+}
+
+// ownerFilter narrows down the records to the ones containing the fields of an
+// owner reference which are set, the reference is matched by Match.
+func ownerFilter(refs query.Value, r metav1.OwnerReference) []query.Expr {
+	var filters []query.Expr
+	for _, v := range []string{r.APIVersion, r.Kind, r.Name, string(r.UID)} {
+		if v != "" {
+			filters = append(filters, query.Contains(refs, v))
+		}
+	}
+	return filters
+}
+
+// Match reports whether an object matches the options which tekton results
+// can't filter by exactly, the records are matched after they are listed.
+func (o *Options) Match(u *unstructured.Unstructured) bool {
+	if !matchMap(u.GetLabels(), o.Labels) || !matchMap(u.GetAnnotations(), o.Annotations) {
+		return false
+	}
+	if o.Selector != nil && !o.Selector.Matches(labels.Set(u.GetLabels())) {
+		return false
+	}
+	for _, f := range o.Finalizers {
+		if !contains(u.GetFinalizers(), f) {
+			return false
+		}
+	}
+	for _, r := range o.OwnerReferences {
+		if !hasOwner(u.GetOwnerReferences(), r) {
+			return false
+		}
+	}
+	return matchAll(u, resultFields, o.Results) && matchAll(u, paramFields, o.Params)
+}
+
+// MatchRun reports whether a run in the cluster matches the options the same
+// way its record is filtered, except for the raw filter which can only be
+// evaluated by tekton results.
+func (o *Options) MatchRun(u *unstructured.Unstructured) bool {
+	switch {
+	case o.Name != "" && u.GetName() != o.Name,
+		o.GenerateName != "" && !strings.HasPrefix(u.GetName(), o.GenerateName),
+		o.UID != "" && u.GetUID() != o.UID,
+		o.Status != "" && !MatchStatus(u, o.Status),
+		!matchMap(u.GetLabels(), o.Runs.Labels()):
+		return false
+	}
+
+	if !o.CreatedAfter.IsZero() && u.GetCreationTimestamp().Time.Before(o.CreatedAfter) {
+		return false
+	}
+	// Runs without the time don't match the time filters, like records.
+	if t := RunTime(u, "startTime"); !o.Since.IsZero() && (t.IsZero() || t.Before(o.Since)) {
+		return false
+	}
+	if t := RunTime(u, "completionTime"); !o.Until.IsZero() && (t.IsZero() || t.After(o.Until)) {
+		return false
+	}
+	return o.Match(u)
+}
+
+// matchMap reports whether m has the keys of values, and their values unless
+// they are empty.
+func matchMap(m, values map[string]string) bool {
+	for k, v := range values {
+		if got, ok := m[k]; !ok || v != "" && got != v {
+			return false
+		}
+	}
+	return true
+}
+
+// hasOwner reports whether an owner reference matches the fields of r which
+// are set.
+func hasOwner(refs []metav1.OwnerReference, r metav1.OwnerReference) bool {
+	for _, ref := range refs {
+		if (r.APIVersion == "" || r.APIVersion == ref.APIVersion) &&
+			(r.Kind == "" || r.Kind == ref.Kind) &&
+			(r.Name == "" || r.Name == ref.Name) &&
+			(r.UID == "" || r.UID == ref.UID) {
+			return true
 		}
 	}
-	return strings.Join(filters, " && ")
+	return false
 }
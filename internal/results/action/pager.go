@@ -0,0 +1,65 @@
+package action
+
+import (
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
+	"io"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type page struct {
+	list *unstructured.UnstructuredList
+	err  error
+}
+
+// Pager lists the records page by page following the next page token. The
+// next page is fetched in the background while the current one is processed.
+type Pager struct {
+	client  client.Client
+	options Options
+	next    chan page
+	done    bool
+}
+
+// NewPager returns a pager starting at the page token in the options.
+func NewPager(c client.Client, o *Options) *Pager {
+	p := &Pager{
+		client:  c,
+		options: *o,
+	}
+	p.fetch(o.Continue)
+	return p
+}
+
+func (p *Pager) fetch(token string) {
+	p.next = make(chan page, 1)
+	o := p.options
+	o.Continue = token
+	go func(c chan<- page) {
+		ul, err := List(p.client, &o)
+		c <- page{list: ul, err: err}
+	}(p.next)
+}
+
+// More reports if there are more pages to fetch.
+func (p *Pager) More() bool {
+	return !p.done
+}
+
+// Next returns the next page, io.EOF is returned after the last page.
+func (p *Pager) Next() (*unstructured.UnstructuredList, error) {
+	if p.done {
+		return nil, io.EOF
+	}
+	r := <-p.next
+	if r.err != nil {
+		p.done = true
+		return nil, r.err
+	}
+	token, _, _ := unstructured.NestedString(r.list.Object, "nextPageToken")
+	if token == "" {
+		p.done = true
+	} else {
+		p.fetch(token)
+	}
+	return r.list, nil
+}
@@ -0,0 +1,84 @@
+package action
+
+import (
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sort"
+)
+
+// TaskRuns lists the TaskRun records of a PipelineRun in pipeline order. The
+// TaskRuns are looked up in the result of the PipelineRun, or by their owner
+// reference if the PipelineRun has no result annotation.
+func TaskRuns(c client.Client, pr *unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	v, k := pr.GroupVersionKind().GroupVersion().WithKind("TaskRun").ToAPIVersionAndKind()
+	o := &Options{
+		ListOptions: metav1.ListOptions{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       k,
+				APIVersion: v,
+			},
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pr.GetNamespace(),
+		},
+		Result: pr.GetAnnotations()[annotation.Result],
+	}
+	if o.Result == "" {
+		o.OwnerReferences = []metav1.OwnerReference{{UID: pr.GetUID()}}
+	}
+
+	var items []unstructured.Unstructured
+	for {
+		ul, err := List(c, o)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, ul.Items...)
+		token, _, _ := unstructured.NestedString(ul.Object, "nextPageToken")
+		if token == "" {
+			break
+		}
+		o.Continue = token
+	}
+
+	SortTaskRuns(pr, items)
+	return items, nil
+}
+
+// SortTaskRuns sorts the TaskRuns in the order of the pipeline tasks of the
+// PipelineRun, TaskRuns of the same or unknown tasks are sorted by start time.
+func SortTaskRuns(pr *unstructured.Unstructured, items []unstructured.Unstructured) {
+	order := pipelineOrder(pr)
+	sort.SliceStable(items, func(i, j int) bool {
+		ti, tj := items[i].GetLabels()[pipeline.PipelineTaskLabelKey], items[j].GetLabels()[pipeline.PipelineTaskLabelKey]
+		oi, iok := order[ti]
+		oj, jok := order[tj]
+		switch {
+		case iok && jok && oi != oj:
+			return oi < oj
+		case iok != jok:
+			return iok
+		}
+		return RunTime(&items[i], "startTime").Before(RunTime(&items[j], "startTime"))
+	})
+}
+
+// pipelineOrder maps the pipeline task names to their position in the
+// pipeline spec, finally tasks are ordered after the tasks.
+func pipelineOrder(pr *unstructured.Unstructured) map[string]int {
+	m := map[string]int{}
+	for _, field := range []string{"tasks", "finally"} {
+		tasks, _, _ := unstructured.NestedSlice(pr.Object, "status", "pipelineSpec", field)
+		for _, t := range tasks {
+			if t, ok := t.(map[string]interface{}); ok {
+				if name, ok := t["name"].(string); ok {
+					m[name] = len(m)
+				}
+			}
+		}
+	}
+	return m
+}
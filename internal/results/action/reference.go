@@ -0,0 +1,107 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/query"
+	results "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"strings"
+)
+
+// IsReference reports if s references a record directly, by the name of a
+// record, <namespace>/results/<result>/records/<record>, the name of a result
+// or the UID of either.
+func IsReference(s string) bool {
+	if strings.Contains(s, "/results/") {
+		return true
+	}
+	_, err := uuid.Parse(s)
+	return err == nil
+}
+
+// Resolve returns the object archived in the record referenced by ref, see
+// IsReference. A result references the record of its summary, usually the
+// PipelineRun or TaskRun it was created for. UIDs are looked up in the
+// namespace, which is "-" for all namespaces.
+func Resolve(c client.Client, namespace, ref string) (*unstructured.Unstructured, error) {
+	if namespace == "" {
+		namespace = "-"
+	}
+
+	var r *results.Record
+	var err error
+	switch {
+	case strings.Contains(ref, "/records/"):
+		r, err = c.GetRecord(context.Background(), &results.GetRecordRequest{Name: ref})
+	case strings.Contains(ref, "/results/"):
+		r, err = summaryRecord(c, ref)
+	default:
+		r, err = recordByUID(c, namespace, ref)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	u := new(unstructured.Unstructured)
+	if err := json.Unmarshal(r.GetData().GetValue(), u); err != nil {
+		return nil, fmt.Errorf("record %s: %w", r.GetName(), err)
+	}
+	return u, nil
+}
+
+// summaryRecord returns the record of the summary of a result.
+func summaryRecord(c client.Client, name string) (*results.Record, error) {
+	res, err := Result(c, name)
+	if err != nil {
+		return nil, err
+	}
+	return recordOf(c, res)
+}
+
+func recordOf(c client.Client, res *results.Result) (*results.Record, error) {
+	record := res.GetSummary().GetRecord()
+	if record == "" {
+		return nil, fmt.Errorf("result %s has no summary record", res.GetName())
+	}
+	return c.GetRecord(context.Background(), &results.GetRecordRequest{Name: record})
+}
+
+// recordByUID returns the record of which the last segment of the name is uid,
+// or else the summary record of the result of the name or UID. Tekton results
+// filters records by the last segment of their names, which is their UID
+// unless it was named otherwise.
+func recordByUID(c client.Client, namespace, uid string) (*results.Record, error) {
+	rl, err := c.ListRecords(context.Background(), &results.ListRecordsRequest{
+		Parent:   namespace + "/results/-",
+		Filter:   query.Eq(query.Field("name"), query.String(uid)).String(),
+		PageSize: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rl.Records) > 0 {
+		return rl.Records[0], nil
+	}
+
+	res, err := Results(c, &Options{
+		ListOptions: metav1.ListOptions{
+			Limit: 1,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uid,
+			Namespace: namespace,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Results) == 0 {
+		return nil, fmt.Errorf("no record or result with the UID %s found", uid)
+	}
+	return recordOf(c, res.Results[0])
+}
@@ -0,0 +1,119 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/query"
+	results "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"strings"
+)
+
+// Results lists a page of the results in the namespace of the options. The
+// name of the options selects a result by its name, which is looked up in the
+// namespace, or by its UID.
+func Results(c client.Client, o *Options) (*results.ListResultsResponse, error) {
+	err := o.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	filters := []query.Expr{query.Raw(o.Filter)}
+	if o.Name != "" {
+		uid, err := resultUID(c, o.Namespace, o.Name)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, query.Eq(query.Field("uid"), query.String(uid)))
+	}
+	filter := query.And(filters...)
+	if err := filter.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.ListResults(context.Background(), &results.ListResultsRequest{
+		Parent:    o.Namespace,
+		Filter:    filter.String(),
+		OrderBy:   o.OrderBy,
+		PageSize:  int32(o.ListOptions.Limit),
+		PageToken: o.ListOptions.Continue,
+	})
+}
+
+// resultUID returns the UID of the result of the name, or the name if it is
+// the UID of a result which is not named by it. Tekton results can't filter
+// results by their names, so they are looked up unless the namespace is "-".
+func resultUID(c client.Client, namespace, name string) (string, error) {
+	if namespace != "-" || strings.Contains(name, "/results/") {
+		r, err := Result(c, ResultName(namespace, name))
+		if err == nil {
+			return r.GetUid(), nil
+		}
+		if grpcstatus.Code(err) != codes.NotFound {
+			return "", err
+		}
+	}
+	if _, err := uuid.Parse(name); err != nil {
+		if namespace == "-" {
+			return "", fmt.Errorf("result %s can only be found by its name in a namespace", name)
+		}
+		return "", fmt.Errorf("result %s not found in the namespace %s", name, namespace)
+	}
+	return name, nil
+}
+
+// Records lists a page of the records of the result of the options, of any
+// type unless a kind is set.
+func Records(c client.Client, o *Options) (*results.ListRecordsResponse, error) {
+	err := o.validate()
+	if err != nil {
+		return nil, err
+	}
+	filter, err := o.filter()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ListRecords(context.Background(), &results.ListRecordsRequest{
+		Parent:    o.parent(),
+		Filter:    filter,
+		OrderBy:   o.OrderBy,
+		PageSize:  int32(o.ListOptions.Limit),
+		PageToken: o.ListOptions.Continue,
+	})
+}
+
+// AllRecords lists the records of all pages of the result of the options.
+func AllRecords(c client.Client, o *Options) ([]*results.Record, error) {
+	var records []*results.Record
+	for {
+		rl, err := Records(c, o)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rl.Records...)
+		if rl.NextPageToken == "" {
+			return records, nil
+		}
+		o.Continue = rl.NextPageToken
+	}
+}
+
+// ResultName returns the name of a result, <namespace>/results/<result>, the
+// name can also be the last segment of it.
+func ResultName(namespace, name string) string {
+	if strings.Contains(name, "/results/") {
+		return name
+	}
+	return namespace + "/results/" + name
+}
+
+// Result returns the result of the name, <namespace>/results/<result>.
+func Result(c client.Client, name string) (*results.Result, error) {
+	return c.GetResult(context.Background(), &results.GetResultRequest{
+		Name: name,
+	})
+}
@@ -0,0 +1,51 @@
+package action
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"time"
+)
+
+// Labels of runs created by Pipelines-as-Code.
+const (
+	pacRepositoryLabelKey  = "pipelinesascode.tekton.dev/repository"
+	pacSHALabelKey         = "pipelinesascode.tekton.dev/sha"
+	pacPullRequestLabelKey = "pipelinesascode.tekton.dev/pull-request"
+)
+
+// RunFilter restricts the records to runs of a pipeline or task, or to runs
+// of a Pipelines-as-Code repository, commit or pull request.
+type RunFilter struct {
+	Pipeline    string
+	Task        string
+	ParentRun   string
+	Repository  string
+	SHA         string
+	PullRequest string
+}
+
+// Labels returns the labels the runs are selected by.
+func (f *RunFilter) Labels() map[string]string {
+	m := map[string]string{}
+	for k, v := range map[string]string{
+		pipeline.PipelineLabelKey:    f.Pipeline,
+		pipeline.TaskLabelKey:        f.Task,
+		pipeline.PipelineRunLabelKey: f.ParentRun,
+		pacRepositoryLabelKey:        f.Repository,
+		pacSHALabelKey:               f.SHA,
+		pacPullRequestLabelKey:       f.PullRequest,
+	} {
+		if v != "" {
+			m[k] = v
+		}
+	}
+	return m
+}
+
+// RunTime returns a time of the status of a run, e.g. startTime, which is zero
+// if it is not set or invalid.
+func RunTime(u *unstructured.Unstructured, field string) time.Time {
+	s, _, _ := unstructured.NestedString(u.Object, "status", field)
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
@@ -0,0 +1,30 @@
+package action
+
+import (
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/query"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// selectorFilter returns the conditions of the requirements of a label
+// selector which tekton results can filter by, the ones selecting values.
+// The other requirements, and negations which tekton results doesn't group
+// correctly, are matched by Match.
+func selectorFilter(s labels.Selector) []query.Expr {
+	m := query.Field("data", "metadata", "labels")
+
+	rs, _ := s.Requirements()
+	filters := make([]query.Expr, 0, len(rs))
+	for _, r := range rs {
+		v := m.Index(r.Key())
+		values := query.Strings(r.Values().List()...)
+
+		switch r.Operator() {
+		case selection.Equals, selection.DoubleEquals:
+			filters = append(filters, query.Eq(v, values[0]))
+		case selection.In:
+			filters = append(filters, query.In(v, values...))
+		}
+	}
+	return filters
+}
@@ -0,0 +1,92 @@
+package action
+
+import (
+	"fmt"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/query"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"strings"
+)
+
+// Statuses are the statuses of runs which can be filtered by.
+var Statuses = []string{"Succeeded", "Failed", "Cancelled", "Running", "Timeout"}
+
+// Reasons of the succeeded condition of PipelineRuns and TaskRuns.
+var (
+	succeededReasons = []string{"Succeeded", "Completed"}
+	cancelledReasons = []string{"Cancelled", "PipelineRunCancelled", "TaskRunCancelled", "CancelledRunFinally", "StoppedRunFinally"}
+	timeoutReasons   = []string{"PipelineRunTimeout", "TaskRunTimeout"}
+)
+
+// status returns the status matching s case insensitively.
+func status(s string) (string, error) {
+	for _, v := range Statuses {
+		if strings.EqualFold(s, v) {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("invalid status %q, should be one of %s", s, strings.Join(Statuses, ", "))
+}
+
+// statusFilter returns the condition of the records with the status, based
+// on the succeeded condition of the run.
+func statusFilter(s string) query.Expr {
+	condition := query.Field("data", "status", "conditions").At(0)
+	reason := condition.Field("reason")
+	state := condition.Field("status")
+
+	switch s {
+	case "Succeeded":
+		return query.In(reason, query.Strings(succeededReasons...)...)
+	case "Failed":
+		return query.And(
+			query.Eq(state, query.String("False")),
+			query.Not(query.In(reason, query.Strings(append(cancelledReasons, timeoutReasons...)...)...)),
+		)
+	case "Cancelled":
+		return query.In(reason, query.Strings(cancelledReasons...)...)
+	case "Running":
+		return query.Eq(state, query.String("Unknown"))
+	case "Timeout":
+		return query.In(reason, query.Strings(timeoutReasons...)...)
+	}
+	return query.Expr{}
+}
+
+// MatchStatus reports whether the succeeded condition of a run has the status,
+// the same way the records are filtered.
+func MatchStatus(u *unstructured.Unstructured, s string) bool {
+	s, err := status(s)
+	if err != nil {
+		return false
+	}
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	var state, reason string
+	if len(conditions) > 0 {
+		c, _ := conditions[0].(map[string]interface{})
+		state, _ = c["status"].(string)
+		reason, _ = c["reason"].(string)
+	}
+
+	switch s {
+	case "Succeeded":
+		return contains(succeededReasons, reason)
+	case "Failed":
+		return state == "False" && !contains(cancelledReasons, reason) && !contains(timeoutReasons, reason)
+	case "Cancelled":
+		return contains(cancelledReasons, reason)
+	case "Running":
+		return state == "Unknown"
+	case "Timeout":
+		return contains(timeoutReasons, reason)
+	}
+	return false
+}
+
+func contains(s []string, v string) bool {
+	for i := range s {
+		if s[i] == v {
+			return true
+		}
+	}
+	return false
+}
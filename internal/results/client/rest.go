@@ -3,9 +3,13 @@ package client
 import (
 	"bytes"
 	"context"
-	"errors"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	v1alpha2 "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -14,6 +18,8 @@ import (
 	"k8s.io/client-go/transport"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -21,8 +27,9 @@ const (
 )
 
 type restClient struct {
-	httpClient *http.Client
-	url        *url.URL
+	httpClient   *http.Client
+	streamClient *http.Client
+	url          *url.URL
 }
 
 // NewRESTClient creates a new REST client.
@@ -48,6 +55,11 @@ func NewRESTClient(o *Options) (Client, error) {
 			Transport: rt,
 			Timeout:   o.Timeout,
 		},
+		// The client timeout includes reading the body, which would cut off
+		// long-running streams, so streams rely on the context instead.
+		streamClient: &http.Client{
+			Transport: rt,
+		},
 		url: u,
 	}
 
@@ -59,118 +71,235 @@ func NewRESTClient(o *Options) (Client, error) {
 // GetResult makes request to get result
 func (c *restClient) GetResult(ctx context.Context, in *v1alpha2.GetResultRequest, _ ...grpc.CallOption) (*v1alpha2.Result, error) {
 	out := &v1alpha2.Result{}
-	return out, c.send(ctx, http.MethodGet, []string{in.Name}, in, out)
+	return out, c.send(ctx, http.MethodGet, []string{in.Name}, query(in, "name"), nil, out)
 }
 
 // ListResults makes request and get result list
 func (c *restClient) ListResults(ctx context.Context, in *v1alpha2.ListResultsRequest, _ ...grpc.CallOption) (*v1alpha2.ListResultsResponse, error) {
 	out := &v1alpha2.ListResultsResponse{}
-	return out, c.send(ctx, http.MethodGet, []string{in.Parent, "results"}, in, out)
+	return out, c.send(ctx, http.MethodGet, []string{in.Parent, "results"}, query(in, "parent"), nil, out)
 }
 
 // DeleteResult makes request to delete result
 func (c *restClient) DeleteResult(ctx context.Context, in *v1alpha2.DeleteResultRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := &emptypb.Empty{}
-	return &emptypb.Empty{}, c.send(ctx, http.MethodDelete, []string{in.Name}, in, out)
+	return out, c.send(ctx, http.MethodDelete, []string{in.Name}, query(in, "name"), nil, out)
 }
 
-func (c *restClient) CreateResult(_ context.Context, _ *v1alpha2.CreateResultRequest, _ ...grpc.CallOption) (*v1alpha2.Result, error) {
-	//TODO implement me
-	panic("not implemented")
+// CreateResult makes request to create result
+func (c *restClient) CreateResult(ctx context.Context, in *v1alpha2.CreateResultRequest, _ ...grpc.CallOption) (*v1alpha2.Result, error) {
+	out := &v1alpha2.Result{}
+	return out, c.send(ctx, http.MethodPost, []string{in.Parent, "results"}, query(in, "parent", "result"), in.GetResult(), out)
 }
 
-func (c *restClient) UpdateResult(_ context.Context, _ *v1alpha2.UpdateResultRequest, _ ...grpc.CallOption) (*v1alpha2.Result, error) {
-	//TODO: implement
-	panic("not implemented")
+// UpdateResult makes request to update result
+func (c *restClient) UpdateResult(ctx context.Context, in *v1alpha2.UpdateResultRequest, _ ...grpc.CallOption) (*v1alpha2.Result, error) {
+	out := &v1alpha2.Result{}
+	name := in.GetResult().GetName()
+	if name == "" {
+		name = in.GetName()
+	}
+	return out, c.send(ctx, http.MethodPatch, []string{name}, query(in, "result"), in.GetResult(), out)
 }
 
 // GetRecord makes request to get record
 func (c *restClient) GetRecord(ctx context.Context, in *v1alpha2.GetRecordRequest, _ ...grpc.CallOption) (*v1alpha2.Record, error) {
 	out := &v1alpha2.Record{}
-	return out, c.send(ctx, http.MethodGet, []string{in.Name}, in, out)
+	return out, c.send(ctx, http.MethodGet, []string{in.Name}, query(in, "name"), nil, out)
 }
 
 // ListRecords makes request to get record list
 func (c *restClient) ListRecords(ctx context.Context, in *v1alpha2.ListRecordsRequest, _ ...grpc.CallOption) (*v1alpha2.ListRecordsResponse, error) {
 	out := &v1alpha2.ListRecordsResponse{}
-	return out, c.send(ctx, http.MethodGet, []string{in.Parent, "records"}, in, out)
+	return out, c.send(ctx, http.MethodGet, []string{in.Parent, "records"}, query(in, "parent"), nil, out)
 }
 
 // DeleteRecord makes request to delete record
 func (c *restClient) DeleteRecord(ctx context.Context, in *v1alpha2.DeleteRecordRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := &emptypb.Empty{}
-	return &emptypb.Empty{}, c.send(ctx, http.MethodDelete, []string{in.Name}, in, out)
+	return out, c.send(ctx, http.MethodDelete, []string{in.Name}, query(in, "name"), nil, out)
 }
 
-func (c *restClient) CreateRecord(_ context.Context, _ *v1alpha2.CreateRecordRequest, _ ...grpc.CallOption) (*v1alpha2.Record, error) {
-	//TODO: implement
-	panic("not implemented")
+// CreateRecord makes request to create record
+func (c *restClient) CreateRecord(ctx context.Context, in *v1alpha2.CreateRecordRequest, _ ...grpc.CallOption) (*v1alpha2.Record, error) {
+	out := &v1alpha2.Record{}
+	return out, c.send(ctx, http.MethodPost, []string{in.Parent, "records"}, query(in, "parent", "record"), in.GetRecord(), out)
 }
 
-func (c *restClient) UpdateRecord(_ context.Context, _ *v1alpha2.UpdateRecordRequest, _ ...grpc.CallOption) (*v1alpha2.Record, error) {
-	//TODO: implement
-	panic("not implemented")
+// UpdateRecord makes request to update record
+func (c *restClient) UpdateRecord(ctx context.Context, in *v1alpha2.UpdateRecordRequest, _ ...grpc.CallOption) (*v1alpha2.Record, error) {
+	out := &v1alpha2.Record{}
+	return out, c.send(ctx, http.MethodPatch, []string{in.GetRecord().GetName()}, query(in, "record"), in.GetRecord(), out)
 }
 
+// GetLog makes request to stream log
 func (c *restClient) GetLog(ctx context.Context, in *v1alpha2.GetLogRequest, _ ...grpc.CallOption) (v1alpha2.Logs_GetLogClient, error) {
-	out := &v1alpha2.Log{}
-	return nil, c.send(ctx, http.MethodGet, []string{in.Name}, in, out)
+	res, err := c.do(ctx, c.streamClient, http.MethodGet, []string{in.Name}, query(in, "name"), nil)
+	if err != nil {
+		return nil, err
+	}
+	return newLogStream(ctx, res), nil
 }
 
+// ListLogs makes request to get log record list
 func (c *restClient) ListLogs(ctx context.Context, in *v1alpha2.ListRecordsRequest, _ ...grpc.CallOption) (*v1alpha2.ListRecordsResponse, error) {
 	out := &v1alpha2.ListRecordsResponse{}
-	return out, c.send(ctx, http.MethodGet, []string{in.Parent, "records"}, in, out)
+	return out, c.send(ctx, http.MethodGet, []string{in.Parent, "logs"}, query(in, "parent"), nil, out)
 }
 
+// DeleteLog makes request to delete log
 func (c *restClient) DeleteLog(ctx context.Context, in *v1alpha2.DeleteLogRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := &emptypb.Empty{}
-	return &emptypb.Empty{}, c.send(ctx, http.MethodDelete, []string{in.Name}, in, out)
+	return out, c.send(ctx, http.MethodDelete, []string{in.Name}, query(in, "name"), nil, out)
 }
 
+// UpdateLog is a client streaming call which has no HTTP mapping in the results API.
 func (c *restClient) UpdateLog(_ context.Context, _ ...grpc.CallOption) (v1alpha2.Logs_UpdateLogClient, error) {
-	panic("not implemented")
+	return nil, status.Error(codes.Unimplemented, "UpdateLog is not supported by the REST client, use the gRPC client")
 }
 
-func (c *restClient) send(ctx context.Context, method string, values []string, in, out proto.Message) error {
-	u := c.url.JoinPath(values...)
-	q := u.Query()
-	in.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
-		if fd.JSONName() == "parent" || !fd.HasJSONName() || fd.Kind() == protoreflect.BytesKind {
-			return true
-		}
-		q.Set(fd.JSONName(), v.String())
-		return true
-	})
+func (c *restClient) send(ctx context.Context, method string, path []string, q url.Values, in, out proto.Message) error {
+	res, err := c.do(ctx, c.httpClient, method, path, q, in)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(b, out)
+}
+
+// do makes the request and returns the response if it succeeded, the caller
+// must close the response body.
+func (c *restClient) do(ctx context.Context, hc *http.Client, method string, path []string, q url.Values, in proto.Message) (*http.Response, error) {
+	u := c.url.JoinPath(path...)
 	u.RawQuery = q.Encode()
 
 	var body io.Reader
-	if method == http.MethodPost || method == http.MethodPut {
+	if in != nil {
 		b, err := protojson.Marshal(in)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		body = bytes.NewReader(b)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
 	}
 
-	res, err := c.httpClient.Do(req)
+	res, err := hc.Do(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return errors.New(http.StatusText(res.StatusCode))
+		defer res.Body.Close()
+		b, err := io.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, decodeError(res.StatusCode, b)
 	}
 
-	defer res.Body.Close()
-	b, err := io.ReadAll(res.Body)
-	if err != nil {
-		return err
+	return res, nil
+}
+
+// query encodes the populated fields of a request as URL query parameters the
+// same way the grpc-gateway expects them. Fields bound to the path or the body
+// are passed as skip.
+func query(m proto.Message, skip ...string) url.Values {
+	q := url.Values{}
+	m.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		name := string(fd.Name())
+		for _, s := range skip {
+			if s == name {
+				return true
+			}
+		}
+		if fd.IsList() {
+			l := v.List()
+			for i := 0; i < l.Len(); i++ {
+				if s, ok := queryValue(fd, l.Get(i)); ok {
+					q.Add(name, s)
+				}
+			}
+			return true
+		}
+		if s, ok := queryValue(fd, v); ok {
+			q.Set(name, s)
+		}
+		return true
+	})
+	return q
+}
+
+func queryValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) (string, bool) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return v.String(), true
+	case protoreflect.BoolKind:
+		return strconv.FormatBool(v.Bool()), true
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return strconv.FormatInt(v.Int(), 10), true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64), true
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name()), true
+		}
+		return strconv.Itoa(int(v.Enum())), true
+	case protoreflect.BytesKind:
+		return base64.URLEncoding.EncodeToString(v.Bytes()), true
+	case protoreflect.MessageKind:
+		// Only well known types with a string JSON form (Timestamp, FieldMask,
+		// Duration) can be represented as a query parameter.
+		b, err := protojson.Marshal(v.Message().Interface())
+		if err != nil {
+			return "", false
+		}
+		s, err := strconv.Unquote(string(b))
+		if err != nil {
+			return "", false
+		}
+		return s, true
 	}
+	return "", false
+}
+
+// decodeError converts the error payload of the results API into a gRPC status
+// error, so callers can handle errors the same way for both clients.
+func decodeError(code int, b []byte) error {
+	s := new(errorStatus)
+	if err := json.Unmarshal(b, s); err == nil && s.Code != codes.OK {
+		return s.Err()
+	}
+	msg := strings.TrimSpace(string(b))
+	if msg == "" {
+		msg = http.StatusText(code)
+	}
+	return fmt.Errorf("%d %s: %s", code, http.StatusText(code), msg)
+}
+
+// errorStatus is the JSON form of google.rpc.Status returned by the gateway.
+type errorStatus struct {
+	Code    codes.Code `json:"code"`
+	Message string     `json:"message"`
+}
 
-	return protojson.Unmarshal(b, out)
+func (s *errorStatus) Err() error {
+	return status.Error(s.Code, s.Message)
 }
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	v1alpha2 "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// logStream implements v1alpha2.Logs_GetLogClient over the chunked HTTP
+// response of the results API. The gateway writes every message of a server
+// stream as a delimited JSON object, either {"result": ...} or {"error": ...}.
+type logStream struct {
+	ctx     context.Context
+	res     *http.Response
+	decoder *json.Decoder
+	err     error
+}
+
+type streamChunk struct {
+	Result json.RawMessage `json:"result"`
+	Error  *errorStatus    `json:"error"`
+}
+
+func newLogStream(ctx context.Context, res *http.Response) *logStream {
+	return &logStream{
+		ctx:     ctx,
+		res:     res,
+		decoder: json.NewDecoder(res.Body),
+	}
+}
+
+// Recv returns the next log message as soon as it is received, io.EOF is
+// returned once the stream is complete.
+func (s *logStream) Recv() (*v1alpha2.Log, error) {
+	l := new(v1alpha2.Log)
+	if err := s.RecvMsg(l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Header returns the response headers as metadata.
+func (s *logStream) Header() (metadata.MD, error) {
+	md := metadata.MD{}
+	for k, v := range s.res.Header {
+		md.Append(strings.ToLower(k), v...)
+	}
+	return md, nil
+}
+
+// Trailer returns the response trailers as metadata, only valid after the stream is complete.
+func (s *logStream) Trailer() metadata.MD {
+	md := metadata.MD{}
+	for k, v := range s.res.Trailer {
+		md.Append(strings.ToLower(k), v...)
+	}
+	return md
+}
+
+// CloseSend closes the response body, further calls to Recv return io.EOF.
+func (s *logStream) CloseSend() error {
+	if s.err == nil {
+		s.err = io.EOF
+	}
+	return s.res.Body.Close()
+}
+
+func (s *logStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *logStream) SendMsg(_ any) error {
+	return errors.New("log stream is receive only")
+}
+
+func (s *logStream) RecvMsg(m any) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return errors.New("log stream can only receive proto messages")
+	}
+
+	c := new(streamChunk)
+	if err := s.decoder.Decode(c); err != nil {
+		return s.fail(err)
+	}
+
+	if c.Error != nil {
+		return s.fail(c.Error.Err())
+	}
+
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(c.Result, msg); err != nil {
+		return s.fail(err)
+	}
+	return nil
+}
+
+// fail closes the stream and records the error which is returned by all subsequent calls.
+func (s *logStream) fail(err error) error {
+	s.err = err
+	_ = s.res.Body.Close()
+	return err
+}
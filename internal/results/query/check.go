@@ -0,0 +1,287 @@
+package query
+
+import (
+	"fmt"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/operators"
+	"github.com/google/cel-go/common/overloads"
+	results "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strings"
+	"sync"
+)
+
+// Variable is a field of a record or a result which can be used in a filter.
+type Variable struct {
+	Name        string
+	Type        *cel.Type
+	Description string
+	Example     string
+}
+
+// RecordVariables are the variables of filters of records, as declared by
+// newRecordsEnv.
+var RecordVariables = []Variable{
+	{"parent", cel.StringType, "Namespace of the record", `parent == "default"`},
+	{"result_name", cel.StringType, "Name of the result of the record, the last segment of its name",
+		`result_name == "f27a6d83-21d3-4256-a8f0-0875b123895f"`},
+	{"name", cel.StringType, "Name of the record, the last segment of <namespace>/results/<result>/records/<record>",
+		`name == "f27a6d83-21d3-4256-a8f0-0875b123895f"`},
+	{"data_type", cel.StringType, "Type of the data of the record, the constants PIPELINE_RUN and TASK_RUN are the types of runs",
+		`data_type == PIPELINE_RUN`},
+	{"data", cel.AnyType, "The archived object, e.g. the PipelineRun or TaskRun",
+		`data.metadata.labels["tekton.dev/pipeline"] == "build"`},
+}
+
+// ResultVariables are the variables of filters of results, as declared by
+// newResultsEnv.
+var ResultVariables = []Variable{
+	{"parent", cel.StringType, "Namespace of the result", `parent == "default"`},
+	{"uid", cel.StringType, "UID of the result", `uid == "f27a6d83-21d3-4256-a8f0-0875b123895f"`},
+	{"annotations", cel.MapType(cel.StringType, cel.StringType), "Annotations of the result",
+		`annotations["repo"] == "tektoncd/results"`},
+	{"summary", cel.ObjectType("tekton.results.v1alpha2.RecordSummary"),
+		"Summary of the result with record, type, start_time, end_time and status, one of UNKNOWN, SUCCESS, FAILURE, TIMEOUT or CANCELLED",
+		`summary.status == FAILURE && summary.type == PIPELINE_RUN`},
+	{"create_time", cel.TimestampType, "Time the result was created",
+		`create_time > timestamp("2023-10-17T00:00:00Z")`},
+	{"update_time", cel.TimestampType, "Time the result was last updated",
+		`update_time > timestamp("2023-10-17T00:00:00Z")`},
+}
+
+// Function is a function or an operator which can be used in a filter.
+type Function struct {
+	Name        string
+	Signature   string
+	Description string
+	Example     string
+}
+
+// Functions are the functions and operators of filters which tekton results
+// can translate to SQL.
+var Functions = []Function{
+	{"==, !=, <, <=, >, >=", "<value> == <value>", "Compare values of the same type",
+		`data.status.conditions[0].reason != "Succeeded"`},
+	{"&&, !", "<bool> && <bool>",
+		"Combine conditions, tekton results drops parentheses so || and ! of combined conditions are not supported",
+		`data_type == PIPELINE_RUN && !(data.metadata.name in ["a", "b"])`},
+	{"in", "<value> in [<value>, ...]", "Check if a value is one of a list",
+		`data.status.conditions[0].reason in ["Failed", "TaskRunTimeout"]`},
+	{"contains", "<string>.contains(<string>)", "Check if a string contains a substring, objects and lists are searched in their JSON",
+		`data.metadata.name.contains("build")`},
+	{"startsWith", "<string>.startsWith(<string>)", "Check if a string starts with a prefix",
+		`data.metadata.name.startsWith("build-")`},
+	{"endsWith", "<string>.endsWith(<string>)", "Check if a string ends with a suffix",
+		`data.metadata.name.endsWith("-nightly")`},
+	{"matches", "<string>.matches(<string>)", "Check if a string matches a regular expression",
+		`data.metadata.name.matches("^build-[0-9]+$")`},
+	{"timestamp", "timestamp(<string>)", "Convert a RFC3339 string to a timestamp",
+		`data.status.startTime > timestamp("2023-10-17T00:00:00Z")`},
+	{"getFullYear, getDayOfYear, getDayOfMonth, getDate, getDayOfWeek", "<timestamp>.getFullYear()",
+		"Get a part of the date of a timestamp", `data.status.startTime.getDayOfWeek() == 1`},
+}
+
+// supportedFunctions are the functions and operators of CEL which tekton
+// results translates to SQL.
+var supportedFunctions = map[string]bool{
+	operators.LogicalNot:           true,
+	operators.LogicalAnd:           true,
+	operators.LogicalOr:            true,
+	operators.Equals:               true,
+	operators.NotEquals:            true,
+	operators.Less:                 true,
+	operators.LessEquals:           true,
+	operators.Greater:              true,
+	operators.GreaterEquals:        true,
+	operators.Add:                  true,
+	operators.Subtract:             true,
+	operators.Multiply:             true,
+	operators.Divide:               true,
+	operators.Modulo:               true,
+	operators.In:                   true,
+	operators.Index:                true,
+	overloads.Contains:             true,
+	overloads.StartsWith:           true,
+	overloads.EndsWith:             true,
+	overloads.Matches:              true,
+	overloads.TypeConvertTimestamp: true,
+	overloads.TimeGetDate:          true,
+	overloads.TimeGetDayOfMonth:    true,
+	overloads.TimeGetDayOfWeek:     true,
+	overloads.TimeGetDayOfYear:     true,
+	overloads.TimeGetFullYear:      true,
+}
+
+const (
+	typePipelineRun = "tekton.dev/v1beta1.PipelineRun"
+	typeTaskRun     = "tekton.dev/v1beta1.TaskRun"
+)
+
+// newResultsEnv is a copy of NewResultsEnv of tekton results v0.8, which the
+// filters of results are checked with.
+func newResultsEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Declarations(stringConst("PIPELINE_RUN", typePipelineRun),
+			stringConst("TASK_RUN", typeTaskRun),
+		),
+		cel.Declarations(recordSummaryStatusConsts()...),
+		cel.Types(&results.RecordSummary{},
+			&timestamppb.Timestamp{}),
+		cel.Variable("parent", cel.StringType),
+		cel.Variable("uid", cel.StringType),
+		cel.Variable("annotations", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("summary",
+			cel.ObjectType("tekton.results.v1alpha2.RecordSummary")),
+		cel.Variable("create_time",
+			cel.ObjectType("google.protobuf.Timestamp")),
+		cel.Variable("update_time",
+			cel.ObjectType("google.protobuf.Timestamp")),
+	)
+}
+
+// newRecordsEnv is a copy of NewRecordsEnv of tekton results v0.8, which the
+// filters of records are checked with.
+func newRecordsEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Declarations(stringConst("PIPELINE_RUN", typePipelineRun),
+			stringConst("TASK_RUN", typeTaskRun),
+		),
+		cel.Variable("parent", cel.StringType),
+		cel.Variable("result_name", cel.StringType),
+		cel.Declarations(decls.NewVar("name", decls.String)),
+		cel.Declarations(decls.NewVar("data_type", decls.String)),
+		cel.Declarations(decls.NewVar("data", decls.Any)),
+	)
+}
+
+func stringConst(name, value string) *exprpb.Decl {
+	return decls.NewConst(name,
+		decls.String,
+		&exprpb.Constant{ConstantKind: &exprpb.Constant_StringValue{StringValue: value}})
+}
+
+func recordSummaryStatusConsts() []*exprpb.Decl {
+	constants := make([]*exprpb.Decl, 0, len(results.RecordSummary_Status_value))
+	for name, value := range results.RecordSummary_Status_value {
+		constants = append(constants, decls.NewConst(name, decls.Int, &exprpb.Constant{ConstantKind: &exprpb.Constant_Int64Value{Int64Value: int64(value)}}))
+	}
+	return constants
+}
+
+// env is a CEL environment which is created when it is first used.
+type env struct {
+	once sync.Once
+	new  func() (*cel.Env, error)
+	env  *cel.Env
+	err  error
+}
+
+func (e *env) get() (*cel.Env, error) {
+	e.once.Do(func() {
+		e.env, e.err = e.new()
+	})
+	return e.env, e.err
+}
+
+var (
+	recordEnv = &env{new: newRecordsEnv}
+	resultEnv = &env{new: newResultsEnv}
+)
+
+// Check type checks a filter of records the same way tekton results does,
+// and checks that it can be translated to SQL. The errors point to the line
+// and column of the filter which is invalid.
+func Check(filter string) error {
+	return check(recordEnv, filter)
+}
+
+// CheckResults type checks a filter of results.
+func CheckResults(filter string) error {
+	return check(resultEnv, filter)
+}
+
+func check(env *env, filter string) error {
+	e, err := env.get()
+	if err != nil {
+		return err
+	}
+	ast, iss := e.Compile(filter)
+	if iss.Err() != nil {
+		return fmt.Errorf("invalid filter:\n%s", iss)
+	}
+	if t := ast.OutputType(); !t.IsExactType(cel.BoolType) && !t.IsExactType(cel.DynType) {
+		return fmt.Errorf("invalid filter: expected a bool expression, got %s", t)
+	}
+	c, err := cel.AstToCheckedExpr(ast)
+	if err != nil {
+		return err
+	}
+	if id, why := unsupported(c, c.GetExpr(), true); id != 0 {
+		l, _ := ast.Source().OffsetLocation(c.GetSourceInfo().GetPositions()[id])
+		return fmt.Errorf("invalid filter at line %d, column %d: %s", l.Line(), l.Column()+1, why)
+	}
+	return nil
+}
+
+// unsupported returns the ID of the first expression which tekton results
+// can't translate to SQL and why, or 0 if there is none. Tekton results drops
+// the parentheses of filters, which are combined with the conditions of the
+// flags by &&, so || can't be used and && only combines the conditions of
+// the filter, not within another expression like !.
+func unsupported(c *exprpb.CheckedExpr, e *exprpb.Expr, conjunct bool) (int64, string) {
+	var children []*exprpb.Expr
+	// Only the operands of && which is a conjunct are conjuncts too.
+	operands := false
+	switch k := e.GetExprKind().(type) {
+	case *exprpb.Expr_IdentExpr:
+	case *exprpb.Expr_ConstExpr:
+		if strings.Contains(k.ConstExpr.GetStringValue(), "'") {
+			return e.GetId(), "' in strings is not supported by tekton results"
+		}
+	case *exprpb.Expr_SelectExpr:
+		if k.SelectExpr.GetTestOnly() {
+			return e.GetId(), "has() is not supported by tekton results"
+		}
+		children = append(children, k.SelectExpr.GetOperand())
+	case *exprpb.Expr_ListExpr:
+		children = k.ListExpr.GetElements()
+	case *exprpb.Expr_CallExpr:
+		f := k.CallExpr.GetFunction()
+		switch {
+		case f == operators.LogicalOr:
+			return e.GetId(), "|| is not supported by tekton results, as it drops the parentheses of filters"
+		case f == operators.LogicalAnd && !conjunct:
+			return e.GetId(), "&& within another expression is not supported by tekton results, as it drops the parentheses of filters"
+		case !supportedFunctions[f]:
+			return e.GetId(), fmt.Sprintf("%s is not supported by tekton results", functionName(f))
+		}
+		args := k.CallExpr.GetArgs()
+		if f == operators.In && c.GetTypeMap()[args[1].GetId()].GetMapType() != nil {
+			return e.GetId(), "in on a map is not supported by tekton results"
+		}
+		if t := k.CallExpr.GetTarget(); t != nil {
+			children = append(children, t)
+		}
+		children = append(children, args...)
+		operands = conjunct && f == operators.LogicalAnd
+	default:
+		// Macros like exists and all, and map and object literals.
+		return e.GetId(), "the expression is not supported by tekton results"
+	}
+	for _, child := range children {
+		if id, why := unsupported(c, child, operands); id != 0 {
+			return id, why
+		}
+	}
+	return 0, ""
+}
+
+// functionName returns the name of a function, or the symbol of an operator.
+func functionName(f string) string {
+	if op, ok := operators.FindReverse(f); ok {
+		return op
+	}
+	return f + "()"
+}
@@ -0,0 +1,234 @@
+// Package query builds CEL filters of tekton results from typed predicates.
+// Literals are always quoted and escaped for CEL. Tekton results writes string
+// literals into SQL without escaping them, so strings with ' are rejected, the
+// error is reported by Err of the filter.
+//
+// Tekton results also drops the parentheses of filters, conditions can only
+// be combined by And.
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Value is an operand of a predicate, a field or a literal.
+type Value struct {
+	s   string
+	err error
+}
+
+func (v Value) String() string {
+	return v.s
+}
+
+// Field returns a field selected by its path, e.g. Field("data", "metadata",
+// "name"). Path elements which are not identifiers are selected by index.
+func Field(path ...string) Value {
+	var v Value
+	for i, p := range path {
+		switch {
+		case i == 0:
+			v.s = p
+		case identifier.MatchString(p):
+			v.s += "." + p
+		default:
+			v = v.Index(p)
+		}
+	}
+	return v
+}
+
+// Field returns a field of the value.
+func (v Value) Field(name string) Value {
+	f := Field(v.s, name)
+	f.err = firstErr(v.err, f.err)
+	return f
+}
+
+// At returns the element of a list at the index.
+func (v Value) At(i int) Value {
+	return Value{v.s + "[" + strconv.Itoa(i) + "]", v.err}
+}
+
+// Index returns the value of a key of a map, e.g. labels["app"].
+func (v Value) Index(key string) Value {
+	k, err := quote(key)
+	return Value{v.s + "[" + k + "]", firstErr(v.err, err)}
+}
+
+// String returns a string literal.
+func String(s string) Value {
+	q, err := quote(s)
+	return Value{q, err}
+}
+
+// Strings returns string literals.
+func Strings(s ...string) []Value {
+	vs := make([]Value, len(s))
+	for i := range s {
+		vs[i] = String(s[i])
+	}
+	return vs
+}
+
+// Int returns an integer literal.
+func Int(i int64) Value {
+	return Value{s: strconv.FormatInt(i, 10)}
+}
+
+// Timestamp returns a timestamp literal.
+func Timestamp(t time.Time) Value {
+	return Value{s: `timestamp("` + t.UTC().Format(time.RFC3339) + `")`}
+}
+
+// Expr is a boolean expression. The zero Expr is empty and is left out of
+// And and Not.
+type Expr struct {
+	s string
+	// compound expressions are wrapped in parentheses when combined.
+	compound bool
+	err      error
+}
+
+func (e Expr) String() string {
+	return e.s
+}
+
+// Err returns the error of the first literal or expression which tekton
+// results can't translate, or nil.
+func (e Expr) Err() error {
+	return e.err
+}
+
+// IsZero reports whether the expression is empty.
+func (e Expr) IsZero() bool {
+	return e.s == ""
+}
+
+// Raw returns an expression written by the user, it is wrapped in
+// parentheses when combined. It has to be checked by Check.
+func Raw(s string) Expr {
+	s = strings.TrimSpace(s)
+	return Expr{s: s, compound: s != ""}
+}
+
+func compare(a Value, op string, b Value) Expr {
+	return Expr{s: a.s + op + b.s, err: firstErr(a.err, b.err)}
+}
+
+// Eq returns a == b.
+func Eq(a, b Value) Expr { return compare(a, "==", b) }
+
+// Ne returns a != b.
+func Ne(a, b Value) Expr { return compare(a, "!=", b) }
+
+// Gt returns a > b.
+func Gt(a, b Value) Expr { return compare(a, ">", b) }
+
+// Ge returns a >= b.
+func Ge(a, b Value) Expr { return compare(a, ">=", b) }
+
+// Lt returns a < b.
+func Lt(a, b Value) Expr { return compare(a, "<", b) }
+
+// Le returns a <= b.
+func Le(a, b Value) Expr { return compare(a, "<=", b) }
+
+// After returns a >= t.
+func After(a Value, t time.Time) Expr { return Ge(a, Timestamp(t)) }
+
+// Before returns a <= t.
+func Before(a Value, t time.Time) Expr { return Le(a, Timestamp(t)) }
+
+// Contains returns v.contains(s). Tekton results searches for s in the text
+// of v, maps and lists are searched in their JSON, so for them it can only
+// narrow down the records which have to be matched exactly.
+func Contains(v Value, s string) Expr {
+	return call(v, "contains", s)
+}
+
+// StartsWith returns v.startsWith(s).
+func StartsWith(v Value, s string) Expr {
+	return call(v, "startsWith", s)
+}
+
+// EndsWith returns v.endsWith(s).
+func EndsWith(v Value, s string) Expr {
+	return call(v, "endsWith", s)
+}
+
+func call(v Value, f, s string) Expr {
+	arg := String(s)
+	return Expr{s: v.s + "." + f + "(" + arg.s + ")", err: firstErr(v.err, arg.err)}
+}
+
+// In returns v in [list].
+func In(v Value, list ...Value) Expr {
+	s := make([]string, len(list))
+	err := v.err
+	for i := range list {
+		s[i] = list[i].s
+		err = firstErr(err, list[i].err)
+	}
+	return Expr{s: v.s + " in [" + strings.Join(s, ",") + "]", err: err}
+}
+
+// And returns the conjunction of the expressions.
+func And(es ...Expr) Expr {
+	var s []string
+	var last Expr
+	var err error
+	for _, e := range es {
+		err = firstErr(err, e.err)
+		switch {
+		case e.IsZero():
+			continue
+		case e.compound:
+			s = append(s, "("+e.s+")")
+		default:
+			s = append(s, e.s)
+		}
+		last = e
+	}
+	if len(s) <= 1 {
+		last.err = err
+		return last
+	}
+	return Expr{s: strings.Join(s, " && "), compound: true, err: err}
+}
+
+// Not returns the negation of the expression. Tekton results would only
+// negate the first condition of a compound expression, which is an error.
+func Not(e Expr) Expr {
+	if e.IsZero() {
+		return e
+	}
+	if e.compound {
+		e.err = firstErr(e.err, fmt.Errorf("tekton results can't negate %s", e.s))
+	}
+	return Expr{s: "!(" + e.s + ")", err: e.err}
+}
+
+// quote returns a CEL string literal. The escapes of Go are a subset of the
+// escapes of CEL.
+func quote(s string) (string, error) {
+	if strings.Contains(s, "'") {
+		return strconv.Quote(s), fmt.Errorf("invalid value %q: tekton results doesn't support ' in strings", s)
+	}
+	return strconv.Quote(s), nil
+}
+
+func firstErr(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
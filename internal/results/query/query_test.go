@@ -0,0 +1,75 @@
+package query
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   Value
+		want    string
+		wantErr bool
+	}{
+		{"field", Field("data", "metadata", "name"), `data.metadata.name`, false},
+		{"field index", Field("data", "metadata", "labels", "tekton.dev/pipeline"), `data.metadata.labels["tekton.dev/pipeline"]`, false},
+		{"field of value", Field("data", "status").Field("startTime"), `data.status.startTime`, false},
+		{"at", Field("data", "status", "conditions").At(0).Field("reason"), `data.status.conditions[0].reason`, false},
+		{"index", Field("data", "metadata", "annotations").Index("a.b/c"), `data.metadata.annotations["a.b/c"]`, false},
+		{"index escaped", Field("labels").Index(`a"] || true || x["`), `labels["a\"] || true || x[\""]`, false},
+		{"index quote", Field("labels").Index("it's"), "", true},
+		{"string", String("build"), `"build"`, false},
+		{"string escaped", String("a\"b\\c\n"), `"a\"b\\c\n"`, false},
+		{"string quote", String("it's"), "", true},
+		{"int", Int(-1), `-1`, false},
+		{"timestamp", Timestamp(time.Date(2023, 10, 17, 0, 0, 0, 0, time.UTC)), `timestamp("2023-10-17T00:00:00Z")`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.value.err; (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && tt.value.String() != tt.want {
+				t.Errorf("got %s, want %s", tt.value, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpr(t *testing.T) {
+	name := Field("data", "metadata", "name")
+	tests := []struct {
+		name    string
+		expr    Expr
+		want    string
+		wantErr bool
+	}{
+		{"eq", Eq(name, String("a")), `data.metadata.name=="a"`, false},
+		{"contains", Contains(name, "a"), `data.metadata.name.contains("a")`, false},
+		{"starts with", StartsWith(name, `a"`), `data.metadata.name.startsWith("a\"")`, false},
+		{"in", In(name, Strings("a", "b")...), `data.metadata.name in ["a","b"]`, false},
+		{"in quote", In(name, Strings("a", "it's")...), "", true},
+		{"and", And(Eq(name, String("a")), Expr{}, Ne(name, String("b"))), `data.metadata.name=="a" && data.metadata.name!="b"`, false},
+		{"and single", And(Expr{}, Eq(name, String("a"))), `data.metadata.name=="a"`, false},
+		{"and empty", And(Expr{}, Raw(" ")), ``, false},
+		{"and raw", And(Raw(`a == "x" && b == "y"`), Eq(name, String("a"))), `(a == "x" && b == "y") && data.metadata.name=="a"`, false},
+		{"and nested", And(And(Eq(name, String("a")), Eq(name, String("b"))), Eq(name, String("c"))),
+			`(data.metadata.name=="a" && data.metadata.name=="b") && data.metadata.name=="c"`, false},
+		{"and error", And(Eq(name, String("a")), Eq(name, String("it's"))), "", true},
+		{"not", Not(In(name, Strings("a")...)), `!(data.metadata.name in ["a"])`, false},
+		{"not empty", Not(Expr{}), ``, false},
+		{"not and", Not(And(Eq(name, String("a")), Eq(name, String("b")))), "", true},
+		{"not raw", Not(Raw(`a == "x"`)), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.expr.Err(); (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && tt.expr.String() != tt.want {
+				t.Errorf("got %s, want %s", tt.expr, tt.want)
+			}
+		})
+	}
+}